@@ -0,0 +1,713 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: api/admin/v1/admin.proto
+
+package admin
+
+import (
+	_ "github.com/easyp-tech/protoc-gen-easydoc/doc/v1"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Request message for moving a channel.
+type MoveChannelRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Plugin version the channel must point to.
+	//
+	// Format: `<group>/<name>:<version>`, the version is an exact version,
+	// `latest`, a version constraint or a channel name.
+	PluginName string `protobuf:"bytes,1,opt,name=plugin_name,json=pluginName,proto3" json:"plugin_name,omitempty"`
+	// Name of the channel.
+	//
+	// Lowercase letters, digits and dashes. `latest`, versions and version constraints
+	// can't be used as channel names.
+	Channel string `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"`
+	// Reason of the move, kept in the channel history.
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveChannelRequest) Reset() {
+	*x = MoveChannelRequest{}
+	mi := &file_api_admin_v1_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveChannelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveChannelRequest) ProtoMessage() {}
+
+func (x *MoveChannelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveChannelRequest.ProtoReflect.Descriptor instead.
+func (*MoveChannelRequest) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MoveChannelRequest) GetPluginName() string {
+	if x != nil {
+		return x.PluginName
+	}
+	return ""
+}
+
+func (x *MoveChannelRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *MoveChannelRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Response message for moving a channel.
+type MoveChannelResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Recorded move.
+	Move          *ChannelMove `protobuf:"bytes,1,opt,name=move,proto3" json:"move,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveChannelResponse) Reset() {
+	*x = MoveChannelResponse{}
+	mi := &file_api_admin_v1_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveChannelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveChannelResponse) ProtoMessage() {}
+
+func (x *MoveChannelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveChannelResponse.ProtoReflect.Descriptor instead.
+func (*MoveChannelResponse) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MoveChannelResponse) GetMove() *ChannelMove {
+	if x != nil {
+		return x.Move
+	}
+	return nil
+}
+
+// Request message for rolling back a channel.
+type RollbackChannelRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Plugin the channel belongs to.
+	//
+	// Format: `<group>/<name>`
+	Plugin string `protobuf:"bytes,1,opt,name=plugin,proto3" json:"plugin,omitempty"`
+	// Name of the channel.
+	Channel string `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"`
+	// Reason of the rollback, kept in the channel history.
+	//
+	// Defaults to `rollback from <version>`.
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackChannelRequest) Reset() {
+	*x = RollbackChannelRequest{}
+	mi := &file_api_admin_v1_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackChannelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackChannelRequest) ProtoMessage() {}
+
+func (x *RollbackChannelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackChannelRequest.ProtoReflect.Descriptor instead.
+func (*RollbackChannelRequest) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RollbackChannelRequest) GetPlugin() string {
+	if x != nil {
+		return x.Plugin
+	}
+	return ""
+}
+
+func (x *RollbackChannelRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *RollbackChannelRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Response message for rolling back a channel.
+type RollbackChannelResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Recorded move.
+	Move          *ChannelMove `protobuf:"bytes,1,opt,name=move,proto3" json:"move,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackChannelResponse) Reset() {
+	*x = RollbackChannelResponse{}
+	mi := &file_api_admin_v1_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackChannelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackChannelResponse) ProtoMessage() {}
+
+func (x *RollbackChannelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackChannelResponse.ProtoReflect.Descriptor instead.
+func (*RollbackChannelResponse) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RollbackChannelResponse) GetMove() *ChannelMove {
+	if x != nil {
+		return x.Move
+	}
+	return nil
+}
+
+// Request message for listing channels.
+type ChannelsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Plugin the channels belong to.
+	//
+	// Format: `<group>/<name>`
+	Plugin        string `protobuf:"bytes,1,opt,name=plugin,proto3" json:"plugin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelsRequest) Reset() {
+	*x = ChannelsRequest{}
+	mi := &file_api_admin_v1_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelsRequest) ProtoMessage() {}
+
+func (x *ChannelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelsRequest.ProtoReflect.Descriptor instead.
+func (*ChannelsRequest) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ChannelsRequest) GetPlugin() string {
+	if x != nil {
+		return x.Plugin
+	}
+	return ""
+}
+
+// Response message for listing channels.
+type ChannelsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Channels of the plugin, sorted by name.
+	Channels      []*Channel `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelsResponse) Reset() {
+	*x = ChannelsResponse{}
+	mi := &file_api_admin_v1_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelsResponse) ProtoMessage() {}
+
+func (x *ChannelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelsResponse.ProtoReflect.Descriptor instead.
+func (*ChannelsResponse) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ChannelsResponse) GetChannels() []*Channel {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+// Request message for showing the history of a channel.
+type ChannelHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Plugin the channel belongs to.
+	//
+	// Format: `<group>/<name>`
+	Plugin string `protobuf:"bytes,1,opt,name=plugin,proto3" json:"plugin,omitempty"`
+	// Name of the channel.
+	Channel       string `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelHistoryRequest) Reset() {
+	*x = ChannelHistoryRequest{}
+	mi := &file_api_admin_v1_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelHistoryRequest) ProtoMessage() {}
+
+func (x *ChannelHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ChannelHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ChannelHistoryRequest) GetPlugin() string {
+	if x != nil {
+		return x.Plugin
+	}
+	return ""
+}
+
+func (x *ChannelHistoryRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+// Response message for showing the history of a channel.
+type ChannelHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Moves of the channel, the latest first.
+	Moves         []*ChannelMove `protobuf:"bytes,1,rep,name=moves,proto3" json:"moves,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelHistoryResponse) Reset() {
+	*x = ChannelHistoryResponse{}
+	mi := &file_api_admin_v1_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelHistoryResponse) ProtoMessage() {}
+
+func (x *ChannelHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelHistoryResponse.ProtoReflect.Descriptor instead.
+func (*ChannelHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ChannelHistoryResponse) GetMoves() []*ChannelMove {
+	if x != nil {
+		return x.Moves
+	}
+	return nil
+}
+
+// Release channel of a plugin.
+type Channel struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the channel.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Version the channel points to.
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Time of the latest move.
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Channel) Reset() {
+	*x = Channel{}
+	mi := &file_api_admin_v1_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Channel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Channel) ProtoMessage() {}
+
+func (x *Channel) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Channel.ProtoReflect.Descriptor instead.
+func (*Channel) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Channel) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Channel) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Channel) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Move of a channel from one version to another.
+type ChannelMove struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the channel.
+	Channel string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	// Version the channel was moved to.
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Version the channel pointed to before, empty if the move created the channel.
+	PreviousVersion string `protobuf:"bytes,3,opt,name=previous_version,json=previousVersion,proto3" json:"previous_version,omitempty"`
+	// Reason given for the move.
+	Reason string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Time of the move.
+	MovedAt       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=moved_at,json=movedAt,proto3" json:"moved_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelMove) Reset() {
+	*x = ChannelMove{}
+	mi := &file_api_admin_v1_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelMove) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelMove) ProtoMessage() {}
+
+func (x *ChannelMove) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelMove.ProtoReflect.Descriptor instead.
+func (*ChannelMove) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ChannelMove) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *ChannelMove) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *ChannelMove) GetPreviousVersion() string {
+	if x != nil {
+		return x.PreviousVersion
+	}
+	return ""
+}
+
+func (x *ChannelMove) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ChannelMove) GetMovedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MovedAt
+	}
+	return nil
+}
+
+var File_api_admin_v1_admin_proto protoreflect.FileDescriptor
+
+const file_api_admin_v1_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x18api/admin/v1/admin.proto\x12\fapi.admin.v1\x1a\x10doc/v1/doc.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa5\x02\n" +
+	"\x12MoveChannelRequest\x12\x8f\x01\n" +
+	"\vplugin_name\x18\x01 \x01(\tBn\xdaIk\b\x01\xa2\x01\x0egrpc/go:v1.5.1\x92\x02U^[a-z][a-z0-9-]*(/[a-z][a-z0-9-]*)+:[A-Za-z0-9_^~<>=!][A-Za-z0-9_.+^~<>=!, -]{0,127}$R\n" +
+	"pluginName\x12A\n" +
+	"\achannel\x18\x02 \x01(\tB'\xdaI$\b\x01\xa2\x01\x06stable\x92\x02\x16^[a-z][a-z0-9-]{0,63}$R\achannel\x12:\n" +
+	"\x06reason\x18\x03 \x01(\tB\"\xdaI\x1f\xa2\x01\x1cpromote after a week in betaR\x06reason\"K\n" +
+	"\x13MoveChannelResponse\x124\n" +
+	"\x04move\x18\x01 \x01(\v2\x19.api.admin.v1.ChannelMoveB\x05\xdaI\x02\x10\x01R\x04move\"\xe9\x01\n" +
+	"\x16RollbackChannelRequest\x12N\n" +
+	"\x06plugin\x18\x01 \x01(\tB6\xdaI3\b\x01\xa2\x01\agrpc/go\x92\x02$^[a-z][a-z0-9-]*(/[a-z][a-z0-9-]*)+$R\x06plugin\x12A\n" +
+	"\achannel\x18\x02 \x01(\tB'\xdaI$\b\x01\xa2\x01\x06stable\x92\x02\x16^[a-z][a-z0-9-]{0,63}$R\achannel\x12<\n" +
+	"\x06reason\x18\x03 \x01(\tB$\xdaI!\xa2\x01\x1egenerated code doesn't compileR\x06reason\"O\n" +
+	"\x17RollbackChannelResponse\x124\n" +
+	"\x04move\x18\x01 \x01(\v2\x19.api.admin.v1.ChannelMoveB\x05\xdaI\x02\x10\x01R\x04move\"a\n" +
+	"\x0fChannelsRequest\x12N\n" +
+	"\x06plugin\x18\x01 \x01(\tB6\xdaI3\b\x01\xa2\x01\agrpc/go\x92\x02$^[a-z][a-z0-9-]*(/[a-z][a-z0-9-]*)+$R\x06plugin\"L\n" +
+	"\x10ChannelsResponse\x128\n" +
+	"\bchannels\x18\x01 \x03(\v2\x15.api.admin.v1.ChannelB\x05\xdaI\x02\x10\x01R\bchannels\"\xaa\x01\n" +
+	"\x15ChannelHistoryRequest\x12N\n" +
+	"\x06plugin\x18\x01 \x01(\tB6\xdaI3\b\x01\xa2\x01\agrpc/go\x92\x02$^[a-z][a-z0-9-]*(/[a-z][a-z0-9-]*)+$R\x06plugin\x12A\n" +
+	"\achannel\x18\x02 \x01(\tB'\xdaI$\b\x01\xa2\x01\x06stable\x92\x02\x16^[a-z][a-z0-9-]{0,63}$R\achannel\"P\n" +
+	"\x16ChannelHistoryResponse\x126\n" +
+	"\x05moves\x18\x01 \x03(\v2\x19.api.admin.v1.ChannelMoveB\x05\xdaI\x02\x10\x01R\x05moves\"\x99\x01\n" +
+	"\aChannel\x12\"\n" +
+	"\x04name\x18\x01 \x01(\tB\x0e\xdaI\v\x10\x01\xa2\x01\x06stableR\x04name\x12(\n" +
+	"\aversion\x18\x02 \x01(\tB\x0e\xdaI\v\x10\x01\xa2\x01\x06v1.5.1R\aversion\x12@\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB\x05\xdaI\x02\x10\x01R\tupdatedAt\"\xf9\x01\n" +
+	"\vChannelMove\x12(\n" +
+	"\achannel\x18\x01 \x01(\tB\x0e\xdaI\v\x10\x01\xa2\x01\x06stableR\achannel\x12(\n" +
+	"\aversion\x18\x02 \x01(\tB\x0e\xdaI\v\x10\x01\xa2\x01\x06v1.5.1R\aversion\x129\n" +
+	"\x10previous_version\x18\x03 \x01(\tB\x0e\xdaI\v\x10\x01\xa2\x01\x06v1.5.0R\x0fpreviousVersion\x12\x1d\n" +
+	"\x06reason\x18\x04 \x01(\tB\x05\xdaI\x02\x10\x01R\x06reason\x12<\n" +
+	"\bmoved_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampB\x05\xdaI\x02\x10\x01R\amovedAt2\xe6\x02\n" +
+	"\bAdminAPI\x12R\n" +
+	"\vMoveChannel\x12 .api.admin.v1.MoveChannelRequest\x1a!.api.admin.v1.MoveChannelResponse\x12^\n" +
+	"\x0fRollbackChannel\x12$.api.admin.v1.RollbackChannelRequest\x1a%.api.admin.v1.RollbackChannelResponse\x12I\n" +
+	"\bChannels\x12\x1d.api.admin.v1.ChannelsRequest\x1a\x1e.api.admin.v1.ChannelsResponse\x12[\n" +
+	"\x0eChannelHistory\x12#.api.admin.v1.ChannelHistoryRequest\x1a$.api.admin.v1.ChannelHistoryResponseB2Z0github.com/easyp-tech/service/api/admin/v1;adminb\x06proto3"
+
+var (
+	file_api_admin_v1_admin_proto_rawDescOnce sync.Once
+	file_api_admin_v1_admin_proto_rawDescData []byte
+)
+
+func file_api_admin_v1_admin_proto_rawDescGZIP() []byte {
+	file_api_admin_v1_admin_proto_rawDescOnce.Do(func() {
+		file_api_admin_v1_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_admin_v1_admin_proto_rawDesc), len(file_api_admin_v1_admin_proto_rawDesc)))
+	})
+	return file_api_admin_v1_admin_proto_rawDescData
+}
+
+var file_api_admin_v1_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_api_admin_v1_admin_proto_goTypes = []any{
+	(*MoveChannelRequest)(nil),      // 0: api.admin.v1.MoveChannelRequest
+	(*MoveChannelResponse)(nil),     // 1: api.admin.v1.MoveChannelResponse
+	(*RollbackChannelRequest)(nil),  // 2: api.admin.v1.RollbackChannelRequest
+	(*RollbackChannelResponse)(nil), // 3: api.admin.v1.RollbackChannelResponse
+	(*ChannelsRequest)(nil),         // 4: api.admin.v1.ChannelsRequest
+	(*ChannelsResponse)(nil),        // 5: api.admin.v1.ChannelsResponse
+	(*ChannelHistoryRequest)(nil),   // 6: api.admin.v1.ChannelHistoryRequest
+	(*ChannelHistoryResponse)(nil),  // 7: api.admin.v1.ChannelHistoryResponse
+	(*Channel)(nil),                 // 8: api.admin.v1.Channel
+	(*ChannelMove)(nil),             // 9: api.admin.v1.ChannelMove
+	(*timestamppb.Timestamp)(nil),   // 10: google.protobuf.Timestamp
+}
+var file_api_admin_v1_admin_proto_depIdxs = []int32{
+	9,  // 0: api.admin.v1.MoveChannelResponse.move:type_name -> api.admin.v1.ChannelMove
+	9,  // 1: api.admin.v1.RollbackChannelResponse.move:type_name -> api.admin.v1.ChannelMove
+	8,  // 2: api.admin.v1.ChannelsResponse.channels:type_name -> api.admin.v1.Channel
+	9,  // 3: api.admin.v1.ChannelHistoryResponse.moves:type_name -> api.admin.v1.ChannelMove
+	10, // 4: api.admin.v1.Channel.updated_at:type_name -> google.protobuf.Timestamp
+	10, // 5: api.admin.v1.ChannelMove.moved_at:type_name -> google.protobuf.Timestamp
+	0,  // 6: api.admin.v1.AdminAPI.MoveChannel:input_type -> api.admin.v1.MoveChannelRequest
+	2,  // 7: api.admin.v1.AdminAPI.RollbackChannel:input_type -> api.admin.v1.RollbackChannelRequest
+	4,  // 8: api.admin.v1.AdminAPI.Channels:input_type -> api.admin.v1.ChannelsRequest
+	6,  // 9: api.admin.v1.AdminAPI.ChannelHistory:input_type -> api.admin.v1.ChannelHistoryRequest
+	1,  // 10: api.admin.v1.AdminAPI.MoveChannel:output_type -> api.admin.v1.MoveChannelResponse
+	3,  // 11: api.admin.v1.AdminAPI.RollbackChannel:output_type -> api.admin.v1.RollbackChannelResponse
+	5,  // 12: api.admin.v1.AdminAPI.Channels:output_type -> api.admin.v1.ChannelsResponse
+	7,  // 13: api.admin.v1.AdminAPI.ChannelHistory:output_type -> api.admin.v1.ChannelHistoryResponse
+	10, // [10:14] is the sub-list for method output_type
+	6,  // [6:10] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_api_admin_v1_admin_proto_init() }
+func file_api_admin_v1_admin_proto_init() {
+	if File_api_admin_v1_admin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_admin_v1_admin_proto_rawDesc), len(file_api_admin_v1_admin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_admin_v1_admin_proto_goTypes,
+		DependencyIndexes: file_api_admin_v1_admin_proto_depIdxs,
+		MessageInfos:      file_api_admin_v1_admin_proto_msgTypes,
+	}.Build()
+	File_api_admin_v1_admin_proto = out.File
+	file_api_admin_v1_admin_proto_goTypes = nil
+	file_api_admin_v1_admin_proto_depIdxs = nil
+}
@@ -0,0 +1,311 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: api/admin/v1/admin.proto
+
+package admin
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AdminAPI_MoveChannel_FullMethodName     = "/api.admin.v1.AdminAPI/MoveChannel"
+	AdminAPI_RollbackChannel_FullMethodName = "/api.admin.v1.AdminAPI/RollbackChannel"
+	AdminAPI_Channels_FullMethodName        = "/api.admin.v1.AdminAPI/Channels"
+	AdminAPI_ChannelHistory_FullMethodName  = "/api.admin.v1.AdminAPI/ChannelHistory"
+)
+
+// AdminAPIClient is the client API for AdminAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// # EasyP Administration Service
+//
+// Operations for plugin maintainers. The service listens on a separate port
+// which must not be exposed to plugin users.
+//
+// ## Release Channels
+//
+// A channel is a named release track of a plugin, such as `stable` or `beta`,
+// which points to one of its versions. Requests to `<group>/<name>:<channel>`
+// use the version the channel points to, so moving a channel upgrades every user of it at once.
+// Every move is kept in the channel history, `RollbackChannel` moves a channel back.
+type AdminAPIClient interface {
+	// Point a channel to a plugin version.
+	//
+	// The channel is created if it doesn't exist. The version may be given as `latest`,
+	// a version constraint or another channel (e.g., `grpc/go:beta` to promote `beta`),
+	// it is resolved to an exact version first.
+	//
+	// ## Error Codes
+	//
+	// | Code | Description |
+	// |------|-------------|
+	// | `NOT_FOUND` | Plugin version not found in registry |
+	// | `INVALID_ARGUMENT` | Invalid plugin name or channel name |
+	// | `FAILED_PRECONDITION` | The version is pending or failed its fixtures |
+	MoveChannel(ctx context.Context, in *MoveChannelRequest, opts ...grpc.CallOption) (*MoveChannelResponse, error)
+	// Move a channel back to the version it pointed to before its latest move.
+	//
+	// The rollback is recorded in the history like any other move,
+	// so rolling back twice returns the channel to the version it was rolled back from.
+	//
+	// ## Error Codes
+	//
+	// | Code | Description |
+	// |------|-------------|
+	// | `NOT_FOUND` | The channel doesn't exist or has no previous version |
+	// | `INVALID_ARGUMENT` | Invalid plugin name or channel name |
+	// | `FAILED_PRECONDITION` | The previous version is no longer available |
+	RollbackChannel(ctx context.Context, in *RollbackChannelRequest, opts ...grpc.CallOption) (*RollbackChannelResponse, error)
+	// List channels of a plugin.
+	Channels(ctx context.Context, in *ChannelsRequest, opts ...grpc.CallOption) (*ChannelsResponse, error)
+	// Show the history of a channel.
+	ChannelHistory(ctx context.Context, in *ChannelHistoryRequest, opts ...grpc.CallOption) (*ChannelHistoryResponse, error)
+}
+
+type adminAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminAPIClient(cc grpc.ClientConnInterface) AdminAPIClient {
+	return &adminAPIClient{cc}
+}
+
+func (c *adminAPIClient) MoveChannel(ctx context.Context, in *MoveChannelRequest, opts ...grpc.CallOption) (*MoveChannelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveChannelResponse)
+	err := c.cc.Invoke(ctx, AdminAPI_MoveChannel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminAPIClient) RollbackChannel(ctx context.Context, in *RollbackChannelRequest, opts ...grpc.CallOption) (*RollbackChannelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RollbackChannelResponse)
+	err := c.cc.Invoke(ctx, AdminAPI_RollbackChannel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminAPIClient) Channels(ctx context.Context, in *ChannelsRequest, opts ...grpc.CallOption) (*ChannelsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChannelsResponse)
+	err := c.cc.Invoke(ctx, AdminAPI_Channels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminAPIClient) ChannelHistory(ctx context.Context, in *ChannelHistoryRequest, opts ...grpc.CallOption) (*ChannelHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChannelHistoryResponse)
+	err := c.cc.Invoke(ctx, AdminAPI_ChannelHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminAPIServer is the server API for AdminAPI service.
+// All implementations should embed UnimplementedAdminAPIServer
+// for forward compatibility.
+//
+// # EasyP Administration Service
+//
+// Operations for plugin maintainers. The service listens on a separate port
+// which must not be exposed to plugin users.
+//
+// ## Release Channels
+//
+// A channel is a named release track of a plugin, such as `stable` or `beta`,
+// which points to one of its versions. Requests to `<group>/<name>:<channel>`
+// use the version the channel points to, so moving a channel upgrades every user of it at once.
+// Every move is kept in the channel history, `RollbackChannel` moves a channel back.
+type AdminAPIServer interface {
+	// Point a channel to a plugin version.
+	//
+	// The channel is created if it doesn't exist. The version may be given as `latest`,
+	// a version constraint or another channel (e.g., `grpc/go:beta` to promote `beta`),
+	// it is resolved to an exact version first.
+	//
+	// ## Error Codes
+	//
+	// | Code | Description |
+	// |------|-------------|
+	// | `NOT_FOUND` | Plugin version not found in registry |
+	// | `INVALID_ARGUMENT` | Invalid plugin name or channel name |
+	// | `FAILED_PRECONDITION` | The version is pending or failed its fixtures |
+	MoveChannel(context.Context, *MoveChannelRequest) (*MoveChannelResponse, error)
+	// Move a channel back to the version it pointed to before its latest move.
+	//
+	// The rollback is recorded in the history like any other move,
+	// so rolling back twice returns the channel to the version it was rolled back from.
+	//
+	// ## Error Codes
+	//
+	// | Code | Description |
+	// |------|-------------|
+	// | `NOT_FOUND` | The channel doesn't exist or has no previous version |
+	// | `INVALID_ARGUMENT` | Invalid plugin name or channel name |
+	// | `FAILED_PRECONDITION` | The previous version is no longer available |
+	RollbackChannel(context.Context, *RollbackChannelRequest) (*RollbackChannelResponse, error)
+	// List channels of a plugin.
+	Channels(context.Context, *ChannelsRequest) (*ChannelsResponse, error)
+	// Show the history of a channel.
+	ChannelHistory(context.Context, *ChannelHistoryRequest) (*ChannelHistoryResponse, error)
+}
+
+// UnimplementedAdminAPIServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAdminAPIServer struct{}
+
+func (UnimplementedAdminAPIServer) MoveChannel(context.Context, *MoveChannelRequest) (*MoveChannelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveChannel not implemented")
+}
+func (UnimplementedAdminAPIServer) RollbackChannel(context.Context, *RollbackChannelRequest) (*RollbackChannelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackChannel not implemented")
+}
+func (UnimplementedAdminAPIServer) Channels(context.Context, *ChannelsRequest) (*ChannelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Channels not implemented")
+}
+func (UnimplementedAdminAPIServer) ChannelHistory(context.Context, *ChannelHistoryRequest) (*ChannelHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChannelHistory not implemented")
+}
+func (UnimplementedAdminAPIServer) testEmbeddedByValue() {}
+
+// UnsafeAdminAPIServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminAPIServer will
+// result in compilation errors.
+type UnsafeAdminAPIServer interface {
+	mustEmbedUnimplementedAdminAPIServer()
+}
+
+func RegisterAdminAPIServer(s grpc.ServiceRegistrar, srv AdminAPIServer) {
+	// If the following call pancis, it indicates UnimplementedAdminAPIServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AdminAPI_ServiceDesc, srv)
+}
+
+func _AdminAPI_MoveChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminAPIServer).MoveChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminAPI_MoveChannel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminAPIServer).MoveChannel(ctx, req.(*MoveChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminAPI_RollbackChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminAPIServer).RollbackChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminAPI_RollbackChannel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminAPIServer).RollbackChannel(ctx, req.(*RollbackChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminAPI_Channels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminAPIServer).Channels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminAPI_Channels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminAPIServer).Channels(ctx, req.(*ChannelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminAPI_ChannelHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminAPIServer).ChannelHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminAPI_ChannelHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminAPIServer).ChannelHistory(ctx, req.(*ChannelHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminAPI_ServiceDesc is the grpc.ServiceDesc for AdminAPI service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminAPI_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "api.admin.v1.AdminAPI",
+	HandlerType: (*AdminAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "MoveChannel",
+			Handler:    _AdminAPI_MoveChannel_Handler,
+		},
+		{
+			MethodName: "RollbackChannel",
+			Handler:    _AdminAPI_RollbackChannel_Handler,
+		},
+		{
+			MethodName: "Channels",
+			Handler:    _AdminAPI_Channels_Handler,
+		},
+		{
+			MethodName: "ChannelHistory",
+			Handler:    _AdminAPI_ChannelHistory_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/admin/v1/admin.proto",
+}
@@ -10,6 +10,8 @@ import (
 	_ "github.com/easyp-tech/protoc-gen-easydoc/doc/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	pluginpb "google.golang.org/protobuf/types/pluginpb"
 	reflect "reflect"
@@ -24,6 +26,238 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Kind of change of a generated file.
+type FileChange int32
+
+const (
+	// Unknown change.
+	FileChange_FILE_CHANGE_NONE FileChange = 0
+	// The file is generated only by the head plugin.
+	FileChange_FILE_CHANGE_ADDED FileChange = 1
+	// The file is generated only by the base plugin.
+	FileChange_FILE_CHANGE_REMOVED FileChange = 2
+	// The file content differs.
+	FileChange_FILE_CHANGE_CHANGED FileChange = 3
+)
+
+// Enum value maps for FileChange.
+var (
+	FileChange_name = map[int32]string{
+		0: "FILE_CHANGE_NONE",
+		1: "FILE_CHANGE_ADDED",
+		2: "FILE_CHANGE_REMOVED",
+		3: "FILE_CHANGE_CHANGED",
+	}
+	FileChange_value = map[string]int32{
+		"FILE_CHANGE_NONE":    0,
+		"FILE_CHANGE_ADDED":   1,
+		"FILE_CHANGE_REMOVED": 2,
+		"FILE_CHANGE_CHANGED": 3,
+	}
+)
+
+func (x FileChange) Enum() *FileChange {
+	p := new(FileChange)
+	*p = x
+	return p
+}
+
+func (x FileChange) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FileChange) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_generator_v1_generator_proto_enumTypes[0].Descriptor()
+}
+
+func (FileChange) Type() protoreflect.EnumType {
+	return &file_api_generator_v1_generator_proto_enumTypes[0]
+}
+
+func (x FileChange) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FileChange.Descriptor instead.
+func (FileChange) EnumDescriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{0}
+}
+
+// Source of a plugin configuration layer.
+type ConfigSource int32
+
+const (
+	// Unknown source.
+	ConfigSource_CONFIG_SOURCE_NONE ConfigSource = 0
+	// Server-wide defaults from the server configuration.
+	ConfigSource_CONFIG_SOURCE_SERVER ConfigSource = 1
+	// Configuration of the plugin group.
+	ConfigSource_CONFIG_SOURCE_GROUP ConfigSource = 2
+	// Configuration shared by all versions of the plugin.
+	ConfigSource_CONFIG_SOURCE_PLUGIN ConfigSource = 3
+	// Configuration of the plugin version.
+	ConfigSource_CONFIG_SOURCE_VERSION ConfigSource = 4
+)
+
+// Enum value maps for ConfigSource.
+var (
+	ConfigSource_name = map[int32]string{
+		0: "CONFIG_SOURCE_NONE",
+		1: "CONFIG_SOURCE_SERVER",
+		2: "CONFIG_SOURCE_GROUP",
+		3: "CONFIG_SOURCE_PLUGIN",
+		4: "CONFIG_SOURCE_VERSION",
+	}
+	ConfigSource_value = map[string]int32{
+		"CONFIG_SOURCE_NONE":    0,
+		"CONFIG_SOURCE_SERVER":  1,
+		"CONFIG_SOURCE_GROUP":   2,
+		"CONFIG_SOURCE_PLUGIN":  3,
+		"CONFIG_SOURCE_VERSION": 4,
+	}
+)
+
+func (x ConfigSource) Enum() *ConfigSource {
+	p := new(ConfigSource)
+	*p = x
+	return p
+}
+
+func (x ConfigSource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConfigSource) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_generator_v1_generator_proto_enumTypes[1].Descriptor()
+}
+
+func (ConfigSource) Type() protoreflect.EnumType {
+	return &file_api_generator_v1_generator_proto_enumTypes[1]
+}
+
+func (x ConfigSource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConfigSource.Descriptor instead.
+func (ConfigSource) EnumDescriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{1}
+}
+
+// Availability of a plugin version.
+type PluginStatus int32
+
+const (
+	// Unknown status.
+	PluginStatus_PLUGIN_STATUS_NONE PluginStatus = 0
+	// The version generates code.
+	PluginStatus_PLUGIN_STATUS_AVAILABLE PluginStatus = 1
+	// Fixtures of the version haven't run yet.
+	PluginStatus_PLUGIN_STATUS_PENDING PluginStatus = 2
+	// Fixtures of the version failed.
+	PluginStatus_PLUGIN_STATUS_FAILED PluginStatus = 3
+)
+
+// Enum value maps for PluginStatus.
+var (
+	PluginStatus_name = map[int32]string{
+		0: "PLUGIN_STATUS_NONE",
+		1: "PLUGIN_STATUS_AVAILABLE",
+		2: "PLUGIN_STATUS_PENDING",
+		3: "PLUGIN_STATUS_FAILED",
+	}
+	PluginStatus_value = map[string]int32{
+		"PLUGIN_STATUS_NONE":      0,
+		"PLUGIN_STATUS_AVAILABLE": 1,
+		"PLUGIN_STATUS_PENDING":   2,
+		"PLUGIN_STATUS_FAILED":    3,
+	}
+)
+
+func (x PluginStatus) Enum() *PluginStatus {
+	p := new(PluginStatus)
+	*p = x
+	return p
+}
+
+func (x PluginStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PluginStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_generator_v1_generator_proto_enumTypes[2].Descriptor()
+}
+
+func (PluginStatus) Type() protoreflect.EnumType {
+	return &file_api_generator_v1_generator_proto_enumTypes[2]
+}
+
+func (x PluginStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PluginStatus.Descriptor instead.
+func (PluginStatus) EnumDescriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{2}
+}
+
+// State of a plugin circuit breaker.
+type CircuitState int32
+
+const (
+	// Unknown state.
+	CircuitState_CIRCUIT_STATE_NONE CircuitState = 0
+	// The plugin is executed normally.
+	CircuitState_CIRCUIT_STATE_CLOSED CircuitState = 1
+	// A trial execution is allowed to check whether the plugin recovered.
+	CircuitState_CIRCUIT_STATE_HALF_OPEN CircuitState = 2
+	// The plugin is failing, requests are rejected without executing it.
+	CircuitState_CIRCUIT_STATE_OPEN CircuitState = 3
+)
+
+// Enum value maps for CircuitState.
+var (
+	CircuitState_name = map[int32]string{
+		0: "CIRCUIT_STATE_NONE",
+		1: "CIRCUIT_STATE_CLOSED",
+		2: "CIRCUIT_STATE_HALF_OPEN",
+		3: "CIRCUIT_STATE_OPEN",
+	}
+	CircuitState_value = map[string]int32{
+		"CIRCUIT_STATE_NONE":      0,
+		"CIRCUIT_STATE_CLOSED":    1,
+		"CIRCUIT_STATE_HALF_OPEN": 2,
+		"CIRCUIT_STATE_OPEN":      3,
+	}
+)
+
+func (x CircuitState) Enum() *CircuitState {
+	p := new(CircuitState)
+	*p = x
+	return p
+}
+
+func (x CircuitState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CircuitState) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_generator_v1_generator_proto_enumTypes[3].Descriptor()
+}
+
+func (CircuitState) Type() protoreflect.EnumType {
+	return &file_api_generator_v1_generator_proto_enumTypes[3]
+}
+
+func (x CircuitState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CircuitState.Descriptor instead.
+func (CircuitState) EnumDescriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{3}
+}
+
 // Request message for code generation.
 type GenerateCodeRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -34,15 +268,28 @@ type GenerateCodeRequest struct {
 	CodeGeneratorRequest *pluginpb.CodeGeneratorRequest `protobuf:"bytes,1,opt,name=code_generator_request,json=codeGeneratorRequest,proto3" json:"code_generator_request,omitempty"`
 	// Name of the plugin to use for generation.
 	//
-	// Format: `<group>/<name>:<version>`
+	// Format: `<group>/<name>[:<version>][@<digest>]`
+	//
+	// The group may contain several segments separated by `/`.
+	// The version is an exact version, `latest`, a version constraint or a channel name.
+	// The digest pins the plugin image content (`sha256:<hex>`).
 	//
 	// Examples:
 	// - `protocolbuffers/go:v1.36.10`
 	// - `grpc/go:v1.5.1`
 	// - `grpc-ecosystem/gateway:latest`
-	PluginName    string `protobuf:"bytes,2,opt,name=plugin_name,json=pluginName,proto3" json:"plugin_name,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// - `grpc/go:stable`
+	// - `grpc/go:^v1.5`
+	// - `grpc/go:v1.5.1@sha256:<hex>`
+	PluginName string `protobuf:"bytes,2,opt,name=plugin_name,json=pluginName,proto3" json:"plugin_name,omitempty"`
+	// Run the plugin twice and compare the responses.
+	//
+	// The first response is returned, differences are reported in `determinism`,
+	// counted in the `plugin_determinism_checks_total` metric and in `PluginInfo.determinism`.
+	// Plugins may also be configured to be verified on every request.
+	VerifyDeterminism bool `protobuf:"varint,3,opt,name=verify_determinism,json=verifyDeterminism,proto3" json:"verify_determinism,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *GenerateCodeRequest) Reset() {
@@ -89,16 +336,34 @@ func (x *GenerateCodeRequest) GetPluginName() string {
 	return ""
 }
 
+func (x *GenerateCodeRequest) GetVerifyDeterminism() bool {
+	if x != nil {
+		return x.VerifyDeterminism
+	}
+	return false
+}
+
 // Response message for code generation.
 type GenerateCodeResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Standard protobuf code generator response.
 	//
 	// Contains the generated files and any error messages from the plugin.
-	// Check the `error` field in the response for plugin-level errors.
+	// Errors reported by the plugin in the `error` field are returned as `INVALID_ARGUMENT`.
 	CodeGeneratorResponse *pluginpb.CodeGeneratorResponse `protobuf:"bytes,1,opt,name=code_generator_response,json=codeGeneratorResponse,proto3" json:"code_generator_response,omitempty"`
-	unknownFields         protoimpl.UnknownFields
-	sizeCache             protoimpl.SizeCache
+	// Plugin which produced the response.
+	//
+	// The version is always exact, even if `latest` or a version constraint was requested,
+	// so clients can record it in a lockfile to reproduce the build.
+	Plugin *PluginInfo `protobuf:"bytes,2,opt,name=plugin,proto3" json:"plugin,omitempty"`
+	// Time spent executing the plugin.
+	Duration *durationpb.Duration `protobuf:"bytes,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	// Whether the response was served from a cache instead of executing the plugin.
+	Cached bool `protobuf:"varint,4,opt,name=cached,proto3" json:"cached,omitempty"`
+	// Result of the determinism check, unset if the request wasn't checked.
+	Determinism   *DeterminismCheck `protobuf:"bytes,5,opt,name=determinism,proto3" json:"determinism,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GenerateCodeResponse) Reset() {
@@ -138,29 +403,61 @@ func (x *GenerateCodeResponse) GetCodeGeneratorResponse() *pluginpb.CodeGenerato
 	return nil
 }
 
-// Request message for listing plugins.
-//
-// Currently accepts no parameters. Future versions may add filtering options.
-type PluginsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+func (x *GenerateCodeResponse) GetPlugin() *PluginInfo {
+	if x != nil {
+		return x.Plugin
+	}
+	return nil
+}
+
+func (x *GenerateCodeResponse) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *GenerateCodeResponse) GetCached() bool {
+	if x != nil {
+		return x.Cached
+	}
+	return false
+}
+
+func (x *GenerateCodeResponse) GetDeterminism() *DeterminismCheck {
+	if x != nil {
+		return x.Determinism
+	}
+	return nil
+}
+
+// Result of running a request twice and comparing the responses.
+type DeterminismCheck struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether both runs produced identical responses.
+	Deterministic bool `protobuf:"varint,1,opt,name=deterministic,proto3" json:"deterministic,omitempty"`
+	// Generated files which differ between the runs, sorted.
+	//
+	// `error` and `supported_features` stand for the response fields of the same name.
+	Files         []string `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PluginsRequest) Reset() {
-	*x = PluginsRequest{}
+func (x *DeterminismCheck) Reset() {
+	*x = DeterminismCheck{}
 	mi := &file_api_generator_v1_generator_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PluginsRequest) String() string {
+func (x *DeterminismCheck) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PluginsRequest) ProtoMessage() {}
+func (*DeterminismCheck) ProtoMessage() {}
 
-func (x *PluginsRequest) ProtoReflect() protoreflect.Message {
+func (x *DeterminismCheck) ProtoReflect() protoreflect.Message {
 	mi := &file_api_generator_v1_generator_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -172,36 +469,52 @@ func (x *PluginsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PluginsRequest.ProtoReflect.Descriptor instead.
-func (*PluginsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeterminismCheck.ProtoReflect.Descriptor instead.
+func (*DeterminismCheck) Descriptor() ([]byte, []int) {
 	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{2}
 }
 
-// Response message for listing plugins.
-type PluginsResponse struct {
+func (x *DeterminismCheck) GetDeterministic() bool {
+	if x != nil {
+		return x.Deterministic
+	}
+	return false
+}
+
+func (x *DeterminismCheck) GetFiles() []string {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+// Request message for comparing code generated by two plugin versions.
+type DiffCodeRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// List of available plugins.
-	//
-	// Plugins are sorted by group, name, and version.
-	Plugins       []*PluginInfo `protobuf:"bytes,1,rep,name=plugins,proto3" json:"plugins,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// Standard protobuf code generator request, sent to both plugins.
+	CodeGeneratorRequest *pluginpb.CodeGeneratorRequest `protobuf:"bytes,1,opt,name=code_generator_request,json=codeGeneratorRequest,proto3" json:"code_generator_request,omitempty"`
+	// Name of the plugin the comparison starts from, in the same format as in `GenerateCodeRequest`.
+	BasePluginName string `protobuf:"bytes,2,opt,name=base_plugin_name,json=basePluginName,proto3" json:"base_plugin_name,omitempty"`
+	// Name of the plugin compared with the base, in the same format as in `GenerateCodeRequest`.
+	HeadPluginName string `protobuf:"bytes,3,opt,name=head_plugin_name,json=headPluginName,proto3" json:"head_plugin_name,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *PluginsResponse) Reset() {
-	*x = PluginsResponse{}
+func (x *DiffCodeRequest) Reset() {
+	*x = DiffCodeRequest{}
 	mi := &file_api_generator_v1_generator_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PluginsResponse) String() string {
+func (x *DiffCodeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PluginsResponse) ProtoMessage() {}
+func (*DiffCodeRequest) ProtoMessage() {}
 
-func (x *PluginsResponse) ProtoReflect() protoreflect.Message {
+func (x *DiffCodeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_generator_v1_generator_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -213,65 +526,65 @@ func (x *PluginsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PluginsResponse.ProtoReflect.Descriptor instead.
-func (*PluginsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DiffCodeRequest.ProtoReflect.Descriptor instead.
+func (*DiffCodeRequest) Descriptor() ([]byte, []int) {
 	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *PluginsResponse) GetPlugins() []*PluginInfo {
+func (x *DiffCodeRequest) GetCodeGeneratorRequest() *pluginpb.CodeGeneratorRequest {
 	if x != nil {
-		return x.Plugins
+		return x.CodeGeneratorRequest
 	}
 	return nil
 }
 
-// Information about a registered plugin.
-type PluginInfo struct {
+func (x *DiffCodeRequest) GetBasePluginName() string {
+	if x != nil {
+		return x.BasePluginName
+	}
+	return ""
+}
+
+func (x *DiffCodeRequest) GetHeadPluginName() string {
+	if x != nil {
+		return x.HeadPluginName
+	}
+	return ""
+}
+
+// Response message for comparing code generated by two plugin versions.
+type DiffCodeResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Unique identifier for the plugin.
-	//
-	// This is an internal UUID assigned when the plugin is registered.
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	// Group to which the plugin belongs.
-	//
-	// Groups organize plugins by maintainer or ecosystem.
-	//
-	// Common groups:
-	// - `protocolbuffers` — Official Google protobuf plugins
-	// - `grpc` — Official gRPC plugins
-	// - `grpc-ecosystem` — gRPC ecosystem plugins (gateway, openapi)
-	// - `community` — Community-maintained plugins
-	Group string `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
-	// Name of the plugin.
-	//
-	// This is the plugin's identifier within its group.
-	//
-	// Examples: `go`, `python`, `gateway`, `openapiv2`
-	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	// Version of the plugin.
-	//
-	// Follows semantic versioning (semver) format.
-	Version string `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
-	// Timestamp when the plugin was registered.
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Base plugin, with the version resolved.
+	BasePlugin *PluginInfo `protobuf:"bytes,1,opt,name=base_plugin,json=basePlugin,proto3" json:"base_plugin,omitempty"`
+	// Head plugin, with the version resolved.
+	HeadPlugin *PluginInfo `protobuf:"bytes,2,opt,name=head_plugin,json=headPlugin,proto3" json:"head_plugin,omitempty"`
+	// Files generated only by the head plugin, sorted.
+	AddedFiles []string `protobuf:"bytes,3,rep,name=added_files,json=addedFiles,proto3" json:"added_files,omitempty"`
+	// Files generated only by the base plugin, sorted.
+	RemovedFiles []string `protobuf:"bytes,4,rep,name=removed_files,json=removedFiles,proto3" json:"removed_files,omitempty"`
+	// Files generated by both plugins with different content, sorted.
+	ChangedFiles []string `protobuf:"bytes,5,rep,name=changed_files,json=changedFiles,proto3" json:"changed_files,omitempty"`
+	// Differences of added, removed and changed files, sorted by file name.
+	Files         []*FileDiff `protobuf:"bytes,6,rep,name=files,proto3" json:"files,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PluginInfo) Reset() {
-	*x = PluginInfo{}
+func (x *DiffCodeResponse) Reset() {
+	*x = DiffCodeResponse{}
 	mi := &file_api_generator_v1_generator_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PluginInfo) String() string {
+func (x *DiffCodeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PluginInfo) ProtoMessage() {}
+func (*DiffCodeResponse) ProtoMessage() {}
 
-func (x *PluginInfo) ProtoReflect() protoreflect.Message {
+func (x *DiffCodeResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_generator_v1_generator_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -283,72 +596,902 @@ func (x *PluginInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PluginInfo.ProtoReflect.Descriptor instead.
-func (*PluginInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use DiffCodeResponse.ProtoReflect.Descriptor instead.
+func (*DiffCodeResponse) Descriptor() ([]byte, []int) {
 	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *PluginInfo) GetId() string {
+func (x *DiffCodeResponse) GetBasePlugin() *PluginInfo {
 	if x != nil {
-		return x.Id
+		return x.BasePlugin
 	}
-	return ""
+	return nil
 }
 
-func (x *PluginInfo) GetGroup() string {
+func (x *DiffCodeResponse) GetHeadPlugin() *PluginInfo {
 	if x != nil {
-		return x.Group
+		return x.HeadPlugin
 	}
-	return ""
+	return nil
 }
 
-func (x *PluginInfo) GetName() string {
+func (x *DiffCodeResponse) GetAddedFiles() []string {
 	if x != nil {
-		return x.Name
+		return x.AddedFiles
 	}
-	return ""
+	return nil
 }
 
-func (x *PluginInfo) GetVersion() string {
+func (x *DiffCodeResponse) GetRemovedFiles() []string {
 	if x != nil {
-		return x.Version
+		return x.RemovedFiles
 	}
-	return ""
+	return nil
 }
 
-func (x *PluginInfo) GetCreatedAt() *timestamppb.Timestamp {
+func (x *DiffCodeResponse) GetChangedFiles() []string {
 	if x != nil {
-		return x.CreatedAt
+		return x.ChangedFiles
 	}
 	return nil
 }
 
-var File_api_generator_v1_generator_proto protoreflect.FileDescriptor
+func (x *DiffCodeResponse) GetFiles() []*FileDiff {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
 
-const file_api_generator_v1_generator_proto_rawDesc = "" +
-	"\n" +
-	" api/generator/v1/generator.proto\x12\x10api.generator.v1\x1a\x10doc/v1/doc.proto\x1a%google/protobuf/compiler/plugin.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8e\x02\n" +
-	"\x13GenerateCodeRequest\x12k\n" +
-	"\x16code_generator_request\x18\x01 \x01(\v2..google.protobuf.compiler.CodeGeneratorRequestB\x05\xdaI\x02\b\x01R\x14codeGeneratorRequest\x12\x89\x01\n" +
-	"\vplugin_name\x18\x02 \x01(\tBh\xdaIe\b\x01\xa2\x01\x1bprotocolbuffers/go:v1.36.10\x92\x02B^[a-z][a-z0-9-]*/[a-z][a-z0-9-]*:(v[0-9]+\\.[0-9]+\\.[0-9]+|latest)$R\n" +
-	"pluginName\"\x86\x01\n" +
-	"\x14GenerateCodeResponse\x12n\n" +
-	"\x17code_generator_response\x18\x01 \x01(\v2/.google.protobuf.compiler.CodeGeneratorResponseB\x05\xdaI\x02\x10\x01R\x15codeGeneratorResponse\"\x10\n" +
-	"\x0ePluginsRequest\"P\n" +
-	"\x0fPluginsResponse\x12=\n" +
-	"\aplugins\x18\x01 \x03(\v2\x1c.api.generator.v1.PluginInfoB\x05\xdaI\x02\x10\x01R\aplugins\"\xa6\x02\n" +
-	"\n" +
-	"PluginInfo\x12\x17\n" +
-	"\x02id\x18\x01 \x01(\tB\a\xdaI\x04\x10\x01P\x01R\x02id\x12A\n" +
-	"\x05group\x18\x02 \x01(\tB+\xdaI(\x10\x01\xa2\x01\x0fprotocolbuffers\x92\x02\x11^[a-z][a-z0-9-]*$R\x05group\x122\n" +
-	"\x04name\x18\x03 \x01(\tB\x1e\xdaI\x1b\x10\x01\xa2\x01\x02go\x92\x02\x11^[a-z][a-z0-9-]*$R\x04name\x12F\n" +
-	"\aversion\x18\x04 \x01(\tB,\xdaI)\x10\x01\xa2\x01\bv1.36.10\x92\x02\x19^v[0-9]+\\.[0-9]+\\.[0-9]+$R\aversion\x12@\n" +
+// Difference of a generated file between two plugin versions.
+type FileDiff struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the generated file.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Kind of the change.
+	Change FileChange `protobuf:"varint,2,opt,name=change,proto3,enum=api.generator.v1.FileChange" json:"change,omitempty"`
+	// Unified diff of the file with three lines of context.
+	//
+	// Files are named `a/<name>` and `b/<name>`, `/dev/null` stands for the missing side
+	// of an added or removed file. Files which aren't valid UTF-8 are reported
+	// as `Binary files a/<name> and b/<name> differ`.
+	UnifiedDiff   string `protobuf:"bytes,3,opt,name=unified_diff,json=unifiedDiff,proto3" json:"unified_diff,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileDiff) Reset() {
+	*x = FileDiff{}
+	mi := &file_api_generator_v1_generator_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileDiff) ProtoMessage() {}
+
+func (x *FileDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_api_generator_v1_generator_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileDiff.ProtoReflect.Descriptor instead.
+func (*FileDiff) Descriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FileDiff) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FileDiff) GetChange() FileChange {
+	if x != nil {
+		return x.Change
+	}
+	return FileChange_FILE_CHANGE_NONE
+}
+
+func (x *FileDiff) GetUnifiedDiff() string {
+	if x != nil {
+		return x.UnifiedDiff
+	}
+	return ""
+}
+
+// Request message for listing plugins.
+//
+// Currently accepts no parameters. Future versions may add filtering options.
+type PluginsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginsRequest) Reset() {
+	*x = PluginsRequest{}
+	mi := &file_api_generator_v1_generator_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginsRequest) ProtoMessage() {}
+
+func (x *PluginsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_generator_v1_generator_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginsRequest.ProtoReflect.Descriptor instead.
+func (*PluginsRequest) Descriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{6}
+}
+
+// Response message for listing plugins.
+type PluginsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// List of available plugins.
+	//
+	// Plugins are sorted by group, name, and version (by semantic versioning precedence).
+	Plugins       []*PluginInfo `protobuf:"bytes,1,rep,name=plugins,proto3" json:"plugins,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginsResponse) Reset() {
+	*x = PluginsResponse{}
+	mi := &file_api_generator_v1_generator_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginsResponse) ProtoMessage() {}
+
+func (x *PluginsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_generator_v1_generator_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginsResponse.ProtoReflect.Descriptor instead.
+func (*PluginsResponse) Descriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PluginsResponse) GetPlugins() []*PluginInfo {
+	if x != nil {
+		return x.Plugins
+	}
+	return nil
+}
+
+// Request message for showing plugin configuration.
+type PluginConfigRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the plugin, in the same format as in `GenerateCodeRequest`.
+	PluginName    string `protobuf:"bytes,1,opt,name=plugin_name,json=pluginName,proto3" json:"plugin_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginConfigRequest) Reset() {
+	*x = PluginConfigRequest{}
+	mi := &file_api_generator_v1_generator_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginConfigRequest) ProtoMessage() {}
+
+func (x *PluginConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_generator_v1_generator_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginConfigRequest.ProtoReflect.Descriptor instead.
+func (*PluginConfigRequest) Descriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PluginConfigRequest) GetPluginName() string {
+	if x != nil {
+		return x.PluginName
+	}
+	return ""
+}
+
+// Response message for showing plugin configuration.
+type PluginConfigResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Plugin the configuration belongs to, with the version resolved.
+	Plugin *PluginInfo `protobuf:"bytes,1,opt,name=plugin,proto3" json:"plugin,omitempty"`
+	// Configuration applied when the plugin is executed, with all layers merged and defaults applied.
+	EffectiveConfig *structpb.Struct `protobuf:"bytes,2,opt,name=effective_config,json=effectiveConfig,proto3" json:"effective_config,omitempty"`
+	// Configuration layers, from the lowest to the highest priority.
+	Layers        []*ConfigLayer `protobuf:"bytes,3,rep,name=layers,proto3" json:"layers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginConfigResponse) Reset() {
+	*x = PluginConfigResponse{}
+	mi := &file_api_generator_v1_generator_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginConfigResponse) ProtoMessage() {}
+
+func (x *PluginConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_generator_v1_generator_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginConfigResponse.ProtoReflect.Descriptor instead.
+func (*PluginConfigResponse) Descriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PluginConfigResponse) GetPlugin() *PluginInfo {
+	if x != nil {
+		return x.Plugin
+	}
+	return nil
+}
+
+func (x *PluginConfigResponse) GetEffectiveConfig() *structpb.Struct {
+	if x != nil {
+		return x.EffectiveConfig
+	}
+	return nil
+}
+
+func (x *PluginConfigResponse) GetLayers() []*ConfigLayer {
+	if x != nil {
+		return x.Layers
+	}
+	return nil
+}
+
+// Partial plugin configuration from a single source.
+type ConfigLayer struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Source of the layer.
+	Source ConfigSource `protobuf:"varint,1,opt,name=source,proto3,enum=api.generator.v1.ConfigSource" json:"source,omitempty"`
+	// Configuration set by the source, empty if the source doesn't configure the plugin.
+	Config        *structpb.Struct `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigLayer) Reset() {
+	*x = ConfigLayer{}
+	mi := &file_api_generator_v1_generator_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigLayer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigLayer) ProtoMessage() {}
+
+func (x *ConfigLayer) ProtoReflect() protoreflect.Message {
+	mi := &file_api_generator_v1_generator_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigLayer.ProtoReflect.Descriptor instead.
+func (*ConfigLayer) Descriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ConfigLayer) GetSource() ConfigSource {
+	if x != nil {
+		return x.Source
+	}
+	return ConfigSource_CONFIG_SOURCE_NONE
+}
+
+func (x *ConfigLayer) GetConfig() *structpb.Struct {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+// Information about a registered plugin.
+type PluginInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unique identifier for the plugin.
+	//
+	// This is an internal UUID assigned when the plugin is registered.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Group to which the plugin belongs.
+	//
+	// Groups organize plugins by maintainer or ecosystem.
+	// A group may be nested (e.g., `org/team`).
+	//
+	// Common groups:
+	// - `protocolbuffers` — Official Google protobuf plugins
+	// - `grpc` — Official gRPC plugins
+	// - `grpc-ecosystem` — gRPC ecosystem plugins (gateway, openapi)
+	// - `community` — Community-maintained plugins
+	Group string `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	// Name of the plugin.
+	//
+	// This is the plugin's identifier within its group.
+	//
+	// Examples: `go`, `python`, `gateway`, `openapiv2`
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// Version of the plugin.
+	//
+	// Follows semantic versioning (semver) format, optionally with a prerelease and build metadata.
+	Version string `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	// Timestamp when the plugin was registered.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Content digest of the plugin image.
+	//
+	// Set if the plugin version is pinned to a digest at registration or the request
+	// was pinned to a digest. Empty if the digest is not known.
+	Digest string `protobuf:"bytes,6,opt,name=digest,proto3" json:"digest,omitempty"`
+	// State of the plugin circuit breaker.
+	//
+	// The circuit opens after consecutive failures of the plugin container,
+	// requests are rejected with `UNAVAILABLE` until a trial execution succeeds.
+	CircuitState CircuitState `protobuf:"varint,7,opt,name=circuit_state,json=circuitState,proto3,enum=api.generator.v1.CircuitState" json:"circuit_state,omitempty"`
+	// Result of the latest canary probe, unset if the plugin wasn't probed yet.
+	//
+	// The service periodically runs a tiny built-in request against registered plugins.
+	Probe *ProbeStatus `protobuf:"bytes,8,opt,name=probe,proto3" json:"probe,omitempty"`
+	// Availability of the plugin version.
+	//
+	// A version registered with fixtures stays pending until they pass,
+	// only available versions generate code and are resolved for `latest`.
+	Status PluginStatus `protobuf:"varint,9,opt,name=status,proto3,enum=api.generator.v1.PluginStatus" json:"status,omitempty"`
+	// Features declared by the plugin, unset until they are probed after registration.
+	//
+	// Requests whose files to generate use features the plugin doesn't support
+	// are rejected with `INVALID_ARGUMENT` without executing the plugin.
+	Capabilities *PluginCapabilities `protobuf:"bytes,10,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
+	// Summary of determinism checks, unset if the version wasn't checked.
+	Determinism *DeterminismStatus `protobuf:"bytes,11,opt,name=determinism,proto3" json:"determinism,omitempty"`
+	// Release channels pointing to the version (e.g., `stable`), sorted.
+	//
+	// Use `<group>/<name>:<channel>` as the plugin name to follow a channel.
+	Channels      []string `protobuf:"bytes,12,rep,name=channels,proto3" json:"channels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginInfo) Reset() {
+	*x = PluginInfo{}
+	mi := &file_api_generator_v1_generator_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginInfo) ProtoMessage() {}
+
+func (x *PluginInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_generator_v1_generator_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginInfo.ProtoReflect.Descriptor instead.
+func (*PluginInfo) Descriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PluginInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PluginInfo) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetCircuitState() CircuitState {
+	if x != nil {
+		return x.CircuitState
+	}
+	return CircuitState_CIRCUIT_STATE_NONE
+}
+
+func (x *PluginInfo) GetProbe() *ProbeStatus {
+	if x != nil {
+		return x.Probe
+	}
+	return nil
+}
+
+func (x *PluginInfo) GetStatus() PluginStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PluginStatus_PLUGIN_STATUS_NONE
+}
+
+func (x *PluginInfo) GetCapabilities() *PluginCapabilities {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+func (x *PluginInfo) GetDeterminism() *DeterminismStatus {
+	if x != nil {
+		return x.Determinism
+	}
+	return nil
+}
+
+func (x *PluginInfo) GetChannels() []string {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+// Summary of determinism checks of a plugin version.
+type DeterminismStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of checks.
+	Checks int64 `protobuf:"varint,1,opt,name=checks,proto3" json:"checks,omitempty"`
+	// Number of checks which found differences.
+	Nondeterministic int64 `protobuf:"varint,2,opt,name=nondeterministic,proto3" json:"nondeterministic,omitempty"`
+	// Files which differed in the latest non-deterministic check.
+	Files []string `protobuf:"bytes,3,rep,name=files,proto3" json:"files,omitempty"`
+	// Time of the latest check.
+	CheckedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	// Time of the latest non-deterministic check, unset if there was none.
+	NondeterministicAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=nondeterministic_at,json=nondeterministicAt,proto3" json:"nondeterministic_at,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *DeterminismStatus) Reset() {
+	*x = DeterminismStatus{}
+	mi := &file_api_generator_v1_generator_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeterminismStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeterminismStatus) ProtoMessage() {}
+
+func (x *DeterminismStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_generator_v1_generator_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeterminismStatus.ProtoReflect.Descriptor instead.
+func (*DeterminismStatus) Descriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeterminismStatus) GetChecks() int64 {
+	if x != nil {
+		return x.Checks
+	}
+	return 0
+}
+
+func (x *DeterminismStatus) GetNondeterministic() int64 {
+	if x != nil {
+		return x.Nondeterministic
+	}
+	return 0
+}
+
+func (x *DeterminismStatus) GetFiles() []string {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *DeterminismStatus) GetCheckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return nil
+}
+
+func (x *DeterminismStatus) GetNondeterministicAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NondeterministicAt
+	}
+	return nil
+}
+
+// Features declared by a plugin in `CodeGeneratorResponse`.
+type PluginCapabilities struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether the plugin supports proto3 optional fields (`FEATURE_PROTO3_OPTIONAL`).
+	Proto3Optional bool `protobuf:"varint,1,opt,name=proto3_optional,json=proto3Optional,proto3" json:"proto3_optional,omitempty"`
+	// Whether the plugin supports editions (`FEATURE_SUPPORTS_EDITIONS`).
+	Editions bool `protobuf:"varint,2,opt,name=editions,proto3" json:"editions,omitempty"`
+	// Earliest supported edition (a `google.protobuf.Edition` name), empty if not declared.
+	MinimumEdition string `protobuf:"bytes,3,opt,name=minimum_edition,json=minimumEdition,proto3" json:"minimum_edition,omitempty"`
+	// Latest supported edition (a `google.protobuf.Edition` name), empty if not declared.
+	MaximumEdition string `protobuf:"bytes,4,opt,name=maximum_edition,json=maximumEdition,proto3" json:"maximum_edition,omitempty"`
+	// Time of the probe.
+	CheckedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginCapabilities) Reset() {
+	*x = PluginCapabilities{}
+	mi := &file_api_generator_v1_generator_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginCapabilities) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginCapabilities) ProtoMessage() {}
+
+func (x *PluginCapabilities) ProtoReflect() protoreflect.Message {
+	mi := &file_api_generator_v1_generator_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginCapabilities.ProtoReflect.Descriptor instead.
+func (*PluginCapabilities) Descriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PluginCapabilities) GetProto3Optional() bool {
+	if x != nil {
+		return x.Proto3Optional
+	}
+	return false
+}
+
+func (x *PluginCapabilities) GetEditions() bool {
+	if x != nil {
+		return x.Editions
+	}
+	return false
+}
+
+func (x *PluginCapabilities) GetMinimumEdition() string {
+	if x != nil {
+		return x.MinimumEdition
+	}
+	return ""
+}
+
+func (x *PluginCapabilities) GetMaximumEdition() string {
+	if x != nil {
+		return x.MaximumEdition
+	}
+	return ""
+}
+
+func (x *PluginCapabilities) GetCheckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return nil
+}
+
+// Result of a canary probe of a plugin.
+type ProbeStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether the plugin successfully generated code for the canary request.
+	Healthy bool `protobuf:"varint,1,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	// Duration of the probe.
+	Latency *durationpb.Duration `protobuf:"bytes,2,opt,name=latency,proto3" json:"latency,omitempty"`
+	// Failure description, empty if the probe succeeded.
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// Time of the probe.
+	CheckedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProbeStatus) Reset() {
+	*x = ProbeStatus{}
+	mi := &file_api_generator_v1_generator_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProbeStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProbeStatus) ProtoMessage() {}
+
+func (x *ProbeStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_generator_v1_generator_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProbeStatus.ProtoReflect.Descriptor instead.
+func (*ProbeStatus) Descriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ProbeStatus) GetHealthy() bool {
+	if x != nil {
+		return x.Healthy
+	}
+	return false
+}
+
+func (x *ProbeStatus) GetLatency() *durationpb.Duration {
+	if x != nil {
+		return x.Latency
+	}
+	return nil
+}
+
+func (x *ProbeStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ProbeStatus) GetCheckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return nil
+}
+
+var File_api_generator_v1_generator_proto protoreflect.FileDescriptor
+
+const file_api_generator_v1_generator_proto_rawDesc = "" +
+	"\n" +
+	" api/generator/v1/generator.proto\x12\x10api.generator.v1\x1a\x10doc/v1/doc.proto\x1a%google/protobuf/compiler/plugin.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x81\x03\n" +
+	"\x13GenerateCodeRequest\x12k\n" +
+	"\x16code_generator_request\x18\x01 \x01(\v2..google.protobuf.compiler.CodeGeneratorRequestB\x05\xdaI\x02\b\x01R\x14codeGeneratorRequest\x12\xcd\x01\n" +
+	"\vplugin_name\x18\x02 \x01(\tB\xab\x01\xdaI\xa7\x01\b\x01\xa2\x01\x1bprotocolbuffers/go:v1.36.10\x92\x02\x83\x01^[a-z][a-z0-9-]*(/[a-z][a-z0-9-]*)+(:[A-Za-z0-9_^~<>=!][A-Za-z0-9_.+^~<>=!, -]{0,127}(@sha256:[a-f0-9]{64})?|@sha256:[a-f0-9]{64})$R\n" +
+	"pluginName\x12-\n" +
+	"\x12verify_determinism\x18\x03 \x01(\bR\x11verifyDeterminism\"\xf6\x02\n" +
+	"\x14GenerateCodeResponse\x12n\n" +
+	"\x17code_generator_response\x18\x01 \x01(\v2/.google.protobuf.compiler.CodeGeneratorResponseB\x05\xdaI\x02\x10\x01R\x15codeGeneratorResponse\x12;\n" +
+	"\x06plugin\x18\x02 \x01(\v2\x1c.api.generator.v1.PluginInfoB\x05\xdaI\x02\x10\x01R\x06plugin\x12E\n" +
+	"\bduration\x18\x03 \x01(\v2\x19.google.protobuf.DurationB\x0e\xdaI\v\x10\x01\xa2\x01\x060.350sR\bduration\x12\x1d\n" +
+	"\x06cached\x18\x04 \x01(\bB\x05\xdaI\x02\x10\x01R\x06cached\x12K\n" +
+	"\vdeterminism\x18\x05 \x01(\v2\".api.generator.v1.DeterminismCheckB\x05\xdaI\x02\x10\x01R\vdeterminism\"\\\n" +
+	"\x10DeterminismCheck\x12+\n" +
+	"\rdeterministic\x18\x01 \x01(\bB\x05\xdaI\x02\x10\x01R\rdeterministic\x12\x1b\n" +
+	"\x05files\x18\x02 \x03(\tB\x05\xdaI\x02\x10\x01R\x05files\"\xaf\x04\n" +
+	"\x0fDiffCodeRequest\x12k\n" +
+	"\x16code_generator_request\x18\x01 \x01(\v2..google.protobuf.compiler.CodeGeneratorRequestB\x05\xdaI\x02\b\x01R\x14codeGeneratorRequest\x12\xd5\x01\n" +
+	"\x10base_plugin_name\x18\x02 \x01(\tB\xaa\x01\xdaI\xa6\x01\b\x01\xa2\x01\x1aprotocolbuffers/go:v1.36.9\x92\x02\x83\x01^[a-z][a-z0-9-]*(/[a-z][a-z0-9-]*)+(:[A-Za-z0-9_^~<>=!][A-Za-z0-9_.+^~<>=!, -]{0,127}(@sha256:[a-f0-9]{64})?|@sha256:[a-f0-9]{64})$R\x0ebasePluginName\x12\xd6\x01\n" +
+	"\x10head_plugin_name\x18\x03 \x01(\tB\xab\x01\xdaI\xa7\x01\b\x01\xa2\x01\x1bprotocolbuffers/go:v1.36.10\x92\x02\x83\x01^[a-z][a-z0-9-]*(/[a-z][a-z0-9-]*)+(:[A-Za-z0-9_^~<>=!][A-Za-z0-9_.+^~<>=!, -]{0,127}(@sha256:[a-f0-9]{64})?|@sha256:[a-f0-9]{64})$R\x0eheadPluginName\"\xd7\x02\n" +
+	"\x10DiffCodeResponse\x12D\n" +
+	"\vbase_plugin\x18\x01 \x01(\v2\x1c.api.generator.v1.PluginInfoB\x05\xdaI\x02\x10\x01R\n" +
+	"basePlugin\x12D\n" +
+	"\vhead_plugin\x18\x02 \x01(\v2\x1c.api.generator.v1.PluginInfoB\x05\xdaI\x02\x10\x01R\n" +
+	"headPlugin\x12&\n" +
+	"\vadded_files\x18\x03 \x03(\tB\x05\xdaI\x02\x10\x01R\n" +
+	"addedFiles\x12*\n" +
+	"\rremoved_files\x18\x04 \x03(\tB\x05\xdaI\x02\x10\x01R\fremovedFiles\x12*\n" +
+	"\rchanged_files\x18\x05 \x03(\tB\x05\xdaI\x02\x10\x01R\fchangedFiles\x127\n" +
+	"\x05files\x18\x06 \x03(\v2\x1a.api.generator.v1.FileDiffB\x05\xdaI\x02\x10\x01R\x05files\"\x9f\x01\n" +
+	"\bFileDiff\x12,\n" +
+	"\x04name\x18\x01 \x01(\tB\x18\xdaI\x15\x10\x01\xa2\x01\x10foo/v1/foo.pb.goR\x04name\x12;\n" +
+	"\x06change\x18\x02 \x01(\x0e2\x1c.api.generator.v1.FileChangeB\x05\xdaI\x02\x10\x01R\x06change\x12(\n" +
+	"\funified_diff\x18\x03 \x01(\tB\x05\xdaI\x02\x10\x01R\vunifiedDiff\"\x10\n" +
+	"\x0ePluginsRequest\"P\n" +
+	"\x0fPluginsResponse\x12=\n" +
+	"\aplugins\x18\x01 \x03(\v2\x1c.api.generator.v1.PluginInfoB\x05\xdaI\x02\x10\x01R\aplugins\"\xe5\x01\n" +
+	"\x13PluginConfigRequest\x12\xcd\x01\n" +
+	"\vplugin_name\x18\x01 \x01(\tB\xab\x01\xdaI\xa7\x01\b\x01\xa2\x01\x1bprotocolbuffers/go:v1.36.10\x92\x02\x83\x01^[a-z][a-z0-9-]*(/[a-z][a-z0-9-]*)+(:[A-Za-z0-9_^~<>=!][A-Za-z0-9_.+^~<>=!, -]{0,127}(@sha256:[a-f0-9]{64})?|@sha256:[a-f0-9]{64})$R\n" +
+	"pluginName\"\xdc\x01\n" +
+	"\x14PluginConfigResponse\x12;\n" +
+	"\x06plugin\x18\x01 \x01(\v2\x1c.api.generator.v1.PluginInfoB\x05\xdaI\x02\x10\x01R\x06plugin\x12I\n" +
+	"\x10effective_config\x18\x02 \x01(\v2\x17.google.protobuf.StructB\x05\xdaI\x02\x10\x01R\x0feffectiveConfig\x12<\n" +
+	"\x06layers\x18\x03 \x03(\v2\x1d.api.generator.v1.ConfigLayerB\x05\xdaI\x02\x10\x01R\x06layers\"\x84\x01\n" +
+	"\vConfigLayer\x12=\n" +
+	"\x06source\x18\x01 \x01(\x0e2\x1e.api.generator.v1.ConfigSourceB\x05\xdaI\x02\x10\x01R\x06source\x126\n" +
+	"\x06config\x18\x02 \x01(\v2\x17.google.protobuf.StructB\x05\xdaI\x02\x10\x01R\x06config\"\xa8\a\n" +
+	"\n" +
+	"PluginInfo\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\tB\a\xdaI\x04\x10\x01P\x01R\x02id\x12T\n" +
+	"\x05group\x18\x02 \x01(\tB>\xdaI;\x10\x01\xa2\x01\x0fprotocolbuffers\x92\x02$^[a-z][a-z0-9-]*(/[a-z][a-z0-9-]*)*$R\x05group\x122\n" +
+	"\x04name\x18\x03 \x01(\tB\x1e\xdaI\x1b\x10\x01\xa2\x01\x02go\x92\x02\x11^[a-z][a-z0-9-]*$R\x04name\x12\xaa\x01\n" +
+	"\aversion\x18\x04 \x01(\tB\x8f\x01\xdaI\x8b\x01\x10\x01\xa2\x01\bv1.36.10\x92\x02{^v(0|[1-9][0-9]*)\\.(0|[1-9][0-9]*)\\.(0|[1-9][0-9]*)(-[0-9A-Za-z-]+(\\.[0-9A-Za-z-]+)*)?(\\+[0-9A-Za-z-]+(\\.[0-9A-Za-z-]+)*)?$R\aversion\x12@\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampB\x05\xdaI\x02\x10\x01R\tcreatedAt\x12\x7f\n" +
+	"\x06digest\x18\x06 \x01(\tBg\xdaId\x10\x01\xa2\x01Gsha256:4f1a0c2d8e5b7a9c3d6e1f0a2b4c6d8e0f1a3b5c7d9e1f2a4b6c8d0e2f4a6b8c\x92\x02\x15^sha256:[a-f0-9]{64}$R\x06digest\x12J\n" +
+	"\rcircuit_state\x18\a \x01(\x0e2\x1e.api.generator.v1.CircuitStateB\x05\xdaI\x02\x10\x01R\fcircuitState\x12:\n" +
+	"\x05probe\x18\b \x01(\v2\x1d.api.generator.v1.ProbeStatusB\x05\xdaI\x02\x10\x01R\x05probe\x12=\n" +
+	"\x06status\x18\t \x01(\x0e2\x1e.api.generator.v1.PluginStatusB\x05\xdaI\x02\x10\x01R\x06status\x12O\n" +
+	"\fcapabilities\x18\n" +
+	" \x01(\v2$.api.generator.v1.PluginCapabilitiesB\x05\xdaI\x02\x10\x01R\fcapabilities\x12L\n" +
+	"\vdeterminism\x18\v \x01(\v2#.api.generator.v1.DeterminismStatusB\x05\xdaI\x02\x10\x01R\vdeterminism\x12!\n" +
+	"\bchannels\x18\f \x03(\tB\x05\xdaI\x02\x10\x01R\bchannels\"\x98\x02\n" +
+	"\x11DeterminismStatus\x12\x1d\n" +
+	"\x06checks\x18\x01 \x01(\x03B\x05\xdaI\x02\x10\x01R\x06checks\x121\n" +
+	"\x10nondeterministic\x18\x02 \x01(\x03B\x05\xdaI\x02\x10\x01R\x10nondeterministic\x12\x1b\n" +
+	"\x05files\x18\x03 \x03(\tB\x05\xdaI\x02\x10\x01R\x05files\x12@\n" +
+	"\n" +
+	"checked_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampB\x05\xdaI\x02\x10\x01R\tcheckedAt\x12R\n" +
+	"\x13nondeterministic_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampB\x05\xdaI\x02\x10\x01R\x12nondeterministicAt\"\xa9\x02\n" +
+	"\x12PluginCapabilities\x12.\n" +
+	"\x0fproto3_optional\x18\x01 \x01(\bB\x05\xdaI\x02\x10\x01R\x0eproto3Optional\x12!\n" +
+	"\beditions\x18\x02 \x01(\bB\x05\xdaI\x02\x10\x01R\beditions\x12?\n" +
+	"\x0fminimum_edition\x18\x03 \x01(\tB\x16\xdaI\x13\x10\x01\xa2\x01\x0eEDITION_PROTO2R\x0eminimumEdition\x12=\n" +
+	"\x0fmaximum_edition\x18\x04 \x01(\tB\x14\xdaI\x11\x10\x01\xa2\x01\fEDITION_2023R\x0emaximumEdition\x12@\n" +
+	"\n" +
+	"checked_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampB\x05\xdaI\x02\x10\x01R\tcheckedAt\"\xd2\x01\n" +
+	"\vProbeStatus\x12\x1f\n" +
+	"\ahealthy\x18\x01 \x01(\bB\x05\xdaI\x02\x10\x01R\ahealthy\x12C\n" +
+	"\alatency\x18\x02 \x01(\v2\x19.google.protobuf.DurationB\x0e\xdaI\v\x10\x01\xa2\x01\x060.350sR\alatency\x12\x1b\n" +
+	"\x05error\x18\x03 \x01(\tB\x05\xdaI\x02\x10\x01R\x05error\x12@\n" +
+	"\n" +
+	"checked_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampB\x05\xdaI\x02\x10\x01R\tcheckedAt*k\n" +
 	"\n" +
-	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampB\x05\xdaI\x02\x10\x01R\tcreatedAt2\xbb\x01\n" +
+	"FileChange\x12\x14\n" +
+	"\x10FILE_CHANGE_NONE\x10\x00\x12\x15\n" +
+	"\x11FILE_CHANGE_ADDED\x10\x01\x12\x17\n" +
+	"\x13FILE_CHANGE_REMOVED\x10\x02\x12\x17\n" +
+	"\x13FILE_CHANGE_CHANGED\x10\x03*\x8e\x01\n" +
+	"\fConfigSource\x12\x16\n" +
+	"\x12CONFIG_SOURCE_NONE\x10\x00\x12\x18\n" +
+	"\x14CONFIG_SOURCE_SERVER\x10\x01\x12\x17\n" +
+	"\x13CONFIG_SOURCE_GROUP\x10\x02\x12\x18\n" +
+	"\x14CONFIG_SOURCE_PLUGIN\x10\x03\x12\x19\n" +
+	"\x15CONFIG_SOURCE_VERSION\x10\x04*x\n" +
+	"\fPluginStatus\x12\x16\n" +
+	"\x12PLUGIN_STATUS_NONE\x10\x00\x12\x1b\n" +
+	"\x17PLUGIN_STATUS_AVAILABLE\x10\x01\x12\x19\n" +
+	"\x15PLUGIN_STATUS_PENDING\x10\x02\x12\x18\n" +
+	"\x14PLUGIN_STATUS_FAILED\x10\x03*u\n" +
+	"\fCircuitState\x12\x16\n" +
+	"\x12CIRCUIT_STATE_NONE\x10\x00\x12\x18\n" +
+	"\x14CIRCUIT_STATE_CLOSED\x10\x01\x12\x1b\n" +
+	"\x17CIRCUIT_STATE_HALF_OPEN\x10\x02\x12\x16\n" +
+	"\x12CIRCUIT_STATE_OPEN\x10\x032\xed\x02\n" +
 	"\n" +
 	"ServiceAPI\x12]\n" +
-	"\fGenerateCode\x12%.api.generator.v1.GenerateCodeRequest\x1a&.api.generator.v1.GenerateCodeResponse\x12N\n" +
-	"\aPlugins\x12 .api.generator.v1.PluginsRequest\x1a!.api.generator.v1.PluginsResponseB:Z8github.com/easyp-tech/service/api/generator/v1;generatorb\x06proto3"
+	"\fGenerateCode\x12%.api.generator.v1.GenerateCodeRequest\x1a&.api.generator.v1.GenerateCodeResponse\x12Q\n" +
+	"\bDiffCode\x12!.api.generator.v1.DiffCodeRequest\x1a\".api.generator.v1.DiffCodeResponse\x12N\n" +
+	"\aPlugins\x12 .api.generator.v1.PluginsRequest\x1a!.api.generator.v1.PluginsResponse\x12]\n" +
+	"\fPluginConfig\x12%.api.generator.v1.PluginConfigRequest\x1a&.api.generator.v1.PluginConfigResponseB:Z8github.com/easyp-tech/service/api/generator/v1;generatorb\x06proto3"
 
 var (
 	file_api_generator_v1_generator_proto_rawDescOnce sync.Once
@@ -362,31 +1505,75 @@ func file_api_generator_v1_generator_proto_rawDescGZIP() []byte {
 	return file_api_generator_v1_generator_proto_rawDescData
 }
 
-var file_api_generator_v1_generator_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_api_generator_v1_generator_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_api_generator_v1_generator_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_api_generator_v1_generator_proto_goTypes = []any{
-	(*GenerateCodeRequest)(nil),            // 0: api.generator.v1.GenerateCodeRequest
-	(*GenerateCodeResponse)(nil),           // 1: api.generator.v1.GenerateCodeResponse
-	(*PluginsRequest)(nil),                 // 2: api.generator.v1.PluginsRequest
-	(*PluginsResponse)(nil),                // 3: api.generator.v1.PluginsResponse
-	(*PluginInfo)(nil),                     // 4: api.generator.v1.PluginInfo
-	(*pluginpb.CodeGeneratorRequest)(nil),  // 5: google.protobuf.compiler.CodeGeneratorRequest
-	(*pluginpb.CodeGeneratorResponse)(nil), // 6: google.protobuf.compiler.CodeGeneratorResponse
-	(*timestamppb.Timestamp)(nil),          // 7: google.protobuf.Timestamp
+	(FileChange)(0),                        // 0: api.generator.v1.FileChange
+	(ConfigSource)(0),                      // 1: api.generator.v1.ConfigSource
+	(PluginStatus)(0),                      // 2: api.generator.v1.PluginStatus
+	(CircuitState)(0),                      // 3: api.generator.v1.CircuitState
+	(*GenerateCodeRequest)(nil),            // 4: api.generator.v1.GenerateCodeRequest
+	(*GenerateCodeResponse)(nil),           // 5: api.generator.v1.GenerateCodeResponse
+	(*DeterminismCheck)(nil),               // 6: api.generator.v1.DeterminismCheck
+	(*DiffCodeRequest)(nil),                // 7: api.generator.v1.DiffCodeRequest
+	(*DiffCodeResponse)(nil),               // 8: api.generator.v1.DiffCodeResponse
+	(*FileDiff)(nil),                       // 9: api.generator.v1.FileDiff
+	(*PluginsRequest)(nil),                 // 10: api.generator.v1.PluginsRequest
+	(*PluginsResponse)(nil),                // 11: api.generator.v1.PluginsResponse
+	(*PluginConfigRequest)(nil),            // 12: api.generator.v1.PluginConfigRequest
+	(*PluginConfigResponse)(nil),           // 13: api.generator.v1.PluginConfigResponse
+	(*ConfigLayer)(nil),                    // 14: api.generator.v1.ConfigLayer
+	(*PluginInfo)(nil),                     // 15: api.generator.v1.PluginInfo
+	(*DeterminismStatus)(nil),              // 16: api.generator.v1.DeterminismStatus
+	(*PluginCapabilities)(nil),             // 17: api.generator.v1.PluginCapabilities
+	(*ProbeStatus)(nil),                    // 18: api.generator.v1.ProbeStatus
+	(*pluginpb.CodeGeneratorRequest)(nil),  // 19: google.protobuf.compiler.CodeGeneratorRequest
+	(*pluginpb.CodeGeneratorResponse)(nil), // 20: google.protobuf.compiler.CodeGeneratorResponse
+	(*durationpb.Duration)(nil),            // 21: google.protobuf.Duration
+	(*structpb.Struct)(nil),                // 22: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil),          // 23: google.protobuf.Timestamp
 }
 var file_api_generator_v1_generator_proto_depIdxs = []int32{
-	5, // 0: api.generator.v1.GenerateCodeRequest.code_generator_request:type_name -> google.protobuf.compiler.CodeGeneratorRequest
-	6, // 1: api.generator.v1.GenerateCodeResponse.code_generator_response:type_name -> google.protobuf.compiler.CodeGeneratorResponse
-	4, // 2: api.generator.v1.PluginsResponse.plugins:type_name -> api.generator.v1.PluginInfo
-	7, // 3: api.generator.v1.PluginInfo.created_at:type_name -> google.protobuf.Timestamp
-	0, // 4: api.generator.v1.ServiceAPI.GenerateCode:input_type -> api.generator.v1.GenerateCodeRequest
-	2, // 5: api.generator.v1.ServiceAPI.Plugins:input_type -> api.generator.v1.PluginsRequest
-	1, // 6: api.generator.v1.ServiceAPI.GenerateCode:output_type -> api.generator.v1.GenerateCodeResponse
-	3, // 7: api.generator.v1.ServiceAPI.Plugins:output_type -> api.generator.v1.PluginsResponse
-	6, // [6:8] is the sub-list for method output_type
-	4, // [4:6] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	19, // 0: api.generator.v1.GenerateCodeRequest.code_generator_request:type_name -> google.protobuf.compiler.CodeGeneratorRequest
+	20, // 1: api.generator.v1.GenerateCodeResponse.code_generator_response:type_name -> google.protobuf.compiler.CodeGeneratorResponse
+	15, // 2: api.generator.v1.GenerateCodeResponse.plugin:type_name -> api.generator.v1.PluginInfo
+	21, // 3: api.generator.v1.GenerateCodeResponse.duration:type_name -> google.protobuf.Duration
+	6,  // 4: api.generator.v1.GenerateCodeResponse.determinism:type_name -> api.generator.v1.DeterminismCheck
+	19, // 5: api.generator.v1.DiffCodeRequest.code_generator_request:type_name -> google.protobuf.compiler.CodeGeneratorRequest
+	15, // 6: api.generator.v1.DiffCodeResponse.base_plugin:type_name -> api.generator.v1.PluginInfo
+	15, // 7: api.generator.v1.DiffCodeResponse.head_plugin:type_name -> api.generator.v1.PluginInfo
+	9,  // 8: api.generator.v1.DiffCodeResponse.files:type_name -> api.generator.v1.FileDiff
+	0,  // 9: api.generator.v1.FileDiff.change:type_name -> api.generator.v1.FileChange
+	15, // 10: api.generator.v1.PluginsResponse.plugins:type_name -> api.generator.v1.PluginInfo
+	15, // 11: api.generator.v1.PluginConfigResponse.plugin:type_name -> api.generator.v1.PluginInfo
+	22, // 12: api.generator.v1.PluginConfigResponse.effective_config:type_name -> google.protobuf.Struct
+	14, // 13: api.generator.v1.PluginConfigResponse.layers:type_name -> api.generator.v1.ConfigLayer
+	1,  // 14: api.generator.v1.ConfigLayer.source:type_name -> api.generator.v1.ConfigSource
+	22, // 15: api.generator.v1.ConfigLayer.config:type_name -> google.protobuf.Struct
+	23, // 16: api.generator.v1.PluginInfo.created_at:type_name -> google.protobuf.Timestamp
+	3,  // 17: api.generator.v1.PluginInfo.circuit_state:type_name -> api.generator.v1.CircuitState
+	18, // 18: api.generator.v1.PluginInfo.probe:type_name -> api.generator.v1.ProbeStatus
+	2,  // 19: api.generator.v1.PluginInfo.status:type_name -> api.generator.v1.PluginStatus
+	17, // 20: api.generator.v1.PluginInfo.capabilities:type_name -> api.generator.v1.PluginCapabilities
+	16, // 21: api.generator.v1.PluginInfo.determinism:type_name -> api.generator.v1.DeterminismStatus
+	23, // 22: api.generator.v1.DeterminismStatus.checked_at:type_name -> google.protobuf.Timestamp
+	23, // 23: api.generator.v1.DeterminismStatus.nondeterministic_at:type_name -> google.protobuf.Timestamp
+	23, // 24: api.generator.v1.PluginCapabilities.checked_at:type_name -> google.protobuf.Timestamp
+	21, // 25: api.generator.v1.ProbeStatus.latency:type_name -> google.protobuf.Duration
+	23, // 26: api.generator.v1.ProbeStatus.checked_at:type_name -> google.protobuf.Timestamp
+	4,  // 27: api.generator.v1.ServiceAPI.GenerateCode:input_type -> api.generator.v1.GenerateCodeRequest
+	7,  // 28: api.generator.v1.ServiceAPI.DiffCode:input_type -> api.generator.v1.DiffCodeRequest
+	10, // 29: api.generator.v1.ServiceAPI.Plugins:input_type -> api.generator.v1.PluginsRequest
+	12, // 30: api.generator.v1.ServiceAPI.PluginConfig:input_type -> api.generator.v1.PluginConfigRequest
+	5,  // 31: api.generator.v1.ServiceAPI.GenerateCode:output_type -> api.generator.v1.GenerateCodeResponse
+	8,  // 32: api.generator.v1.ServiceAPI.DiffCode:output_type -> api.generator.v1.DiffCodeResponse
+	11, // 33: api.generator.v1.ServiceAPI.Plugins:output_type -> api.generator.v1.PluginsResponse
+	13, // 34: api.generator.v1.ServiceAPI.PluginConfig:output_type -> api.generator.v1.PluginConfigResponse
+	31, // [31:35] is the sub-list for method output_type
+	27, // [27:31] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
 }
 
 func init() { file_api_generator_v1_generator_proto_init() }
@@ -399,13 +1586,14 @@ func file_api_generator_v1_generator_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_generator_v1_generator_proto_rawDesc), len(file_api_generator_v1_generator_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   5,
+			NumEnums:      4,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_api_generator_v1_generator_proto_goTypes,
 		DependencyIndexes: file_api_generator_v1_generator_proto_depIdxs,
+		EnumInfos:         file_api_generator_v1_generator_proto_enumTypes,
 		MessageInfos:      file_api_generator_v1_generator_proto_msgTypes,
 	}.Build()
 	File_api_generator_v1_generator_proto = out.File
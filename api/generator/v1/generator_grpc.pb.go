@@ -20,7 +20,9 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	ServiceAPI_GenerateCode_FullMethodName = "/api.generator.v1.ServiceAPI/GenerateCode"
+	ServiceAPI_DiffCode_FullMethodName     = "/api.generator.v1.ServiceAPI/DiffCode"
 	ServiceAPI_Plugins_FullMethodName      = "/api.generator.v1.ServiceAPI/Plugins"
+	ServiceAPI_PluginConfig_FullMethodName = "/api.generator.v1.ServiceAPI/PluginConfig"
 )
 
 // ServiceAPIClient is the client API for ServiceAPI service.
@@ -48,15 +50,31 @@ const (
 //
 // ## Plugin Naming Convention
 //
-// Plugins are identified using the format: `<group>/<name>:<version>`
+// Plugins are identified using the format: `<group>/<name>[:<version>][@<digest>]`
 //
 // Examples:
 // - `protocolbuffers/go:v1.36.10` — Official Go protobuf plugin
 // - `grpc/go:v1.5.1` — Official gRPC Go plugin
 // - `grpc-ecosystem/gateway:v2.27.3` — gRPC-Gateway plugin
+// - `org/team/plugin:v1.0.0` — Plugin in a nested group
 //
-// Use `latest` as version to get the most recent version:
+// Use `latest` as version to get the most recent stable version:
 // - `protocolbuffers/go:latest`
+//
+// Use a version constraint to get the most recent stable version in a range:
+// - `protocolbuffers/go:^v1.36` — `>=v1.36.0 <v2.0.0`
+// - `grpc-ecosystem/gateway:~v2.27.0` — `>=v2.27.0 <v2.28.0`
+// - `grpc/go:>=v1.5 <v2` — explicit comparators
+//
+// Versions are ordered by semantic versioning, prereleases are only selected
+// when the constraint names a prerelease explicitly.
+//
+// Use a named channel to follow a release track:
+// - `grpc/go:stable`
+//
+// Pin the exact image content with a digest, optionally together with a version:
+// - `grpc/go@sha256:<hex>`
+// - `grpc/go:v1.5.1@sha256:<hex>`
 type ServiceAPIClient interface {
 	// Generate code using a specified plugin.
 	//
@@ -64,23 +82,64 @@ type ServiceAPIClient interface {
 	// The plugin runs in an isolated Docker container with the following default limits:
 	//
 	// - **Network**: Disabled (no external access)
-	// - **Memory**: 128MB
+	// - **Memory**: 128MB, no swap
 	// - **CPU**: 1.0 core
+	// - **Processes**: 64
+	// - **Capabilities**: All dropped, no new privileges
+	// - **Time**: 1 minute, capped by the server maximum
+	// - **Output**: 64MB of stdout, 10000 files
 	//
 	// ## Error Codes
 	//
 	// | Code | Description |
 	// |------|-------------|
 	// | `NOT_FOUND` | Plugin not found in registry |
-	// | `INVALID_ARGUMENT` | Invalid plugin name format |
-	// | `INTERNAL` | Plugin execution failed |
+	// | `INVALID_ARGUMENT` | Invalid plugin name format, inconsistent `CodeGeneratorRequest`, request size limits exceeded or files use editions or proto3 optional fields the plugin doesn't support (see `google.rpc.BadRequest` details), or the plugin reported an error |
+	// | `FAILED_PRECONDITION` | Requested digest differs from the pinned digest, the plugin is unsigned or mis-signed, its image can't be pulled, or the version is pending or failed its fixtures |
+	// | `INTERNAL` | Plugin exited with a non-zero code or generated an unsafe file name (absolute, escaping the output directory, duplicate) |
 	// | `DEADLINE_EXCEEDED` | Plugin execution timeout |
+	// | `RESOURCE_EXHAUSTED` | Plugin was killed for exceeding its memory limit, or its output exceeds the size or file count limit |
+	// | `UNAVAILABLE` | Container runtime is unavailable, or the plugin circuit breaker is open after consecutive failures; the request may be retried later |
+	//
+	// Plugin execution failures carry `google.rpc.ErrorInfo` details with the failure reason
+	// (`PLUGIN_ERROR`, `PLUGIN_EXIT_CODE`, `PLUGIN_OOM_KILLED`, `PLUGIN_TIMEOUT`, `PLUGIN_IMAGE_PULL`, `DAEMON_UNAVAILABLE`),
+	// plugin, exit code and duration in metadata, and `google.rpc.DebugInfo` details with the beginning of the plugin stderr.
 	GenerateCode(ctx context.Context, in *GenerateCodeRequest, opts ...grpc.CallOption) (*GenerateCodeResponse, error)
+	// Compare code generated by two plugin versions.
+	//
+	// Runs the same `CodeGeneratorRequest` against both plugins, the way `GenerateCode` does,
+	// and compares the generated files after applying insertion points. Use it to preview
+	// what upgrading a plugin changes in the generated code before bumping the version.
+	//
+	// ## Error Codes
+	//
+	// Errors of either plugin are returned as in `GenerateCode`, the message names the plugin.
+	DiffCode(ctx context.Context, in *DiffCodeRequest, opts ...grpc.CallOption) (*DiffCodeResponse, error)
 	// List available plugins.
 	//
 	// Returns a list of all plugins registered in the service.
 	// Use this to discover available plugins and their versions.
 	Plugins(ctx context.Context, in *PluginsRequest, opts ...grpc.CallOption) (*PluginsResponse, error)
+	// Show the effective configuration of a plugin.
+	//
+	// Plugin configuration is merged from layers, later layers override earlier ones:
+	//
+	// 1. Server defaults
+	// 2. Group configuration
+	// 3. Plugin configuration, shared by all versions
+	// 4. Version configuration
+	//
+	// Objects are merged recursively, other values replace the previous ones,
+	// `null` resets a field to its default. Secure defaults are applied to omitted fields.
+	//
+	// ## Error Codes
+	//
+	// | Code | Description |
+	// |------|-------------|
+	// | `NOT_FOUND` | Plugin not found in registry |
+	// | `INVALID_ARGUMENT` | Invalid plugin name format |
+	// | `FAILED_PRECONDITION` | The merged configuration is invalid |
+	PluginConfig(ctx context.Context, in *PluginConfigRequest, opts ...grpc.CallOption) (*PluginConfigResponse, error)
 }
 
 type serviceAPIClient struct {
@@ -101,6 +160,16 @@ func (c *serviceAPIClient) GenerateCode(ctx context.Context, in *GenerateCodeReq
 	return out, nil
 }
 
+func (c *serviceAPIClient) DiffCode(ctx context.Context, in *DiffCodeRequest, opts ...grpc.CallOption) (*DiffCodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffCodeResponse)
+	err := c.cc.Invoke(ctx, ServiceAPI_DiffCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *serviceAPIClient) Plugins(ctx context.Context, in *PluginsRequest, opts ...grpc.CallOption) (*PluginsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PluginsResponse)
@@ -111,6 +180,16 @@ func (c *serviceAPIClient) Plugins(ctx context.Context, in *PluginsRequest, opts
 	return out, nil
 }
 
+func (c *serviceAPIClient) PluginConfig(ctx context.Context, in *PluginConfigRequest, opts ...grpc.CallOption) (*PluginConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PluginConfigResponse)
+	err := c.cc.Invoke(ctx, ServiceAPI_PluginConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ServiceAPIServer is the server API for ServiceAPI service.
 // All implementations should embed UnimplementedServiceAPIServer
 // for forward compatibility.
@@ -136,15 +215,31 @@ func (c *serviceAPIClient) Plugins(ctx context.Context, in *PluginsRequest, opts
 //
 // ## Plugin Naming Convention
 //
-// Plugins are identified using the format: `<group>/<name>:<version>`
+// Plugins are identified using the format: `<group>/<name>[:<version>][@<digest>]`
 //
 // Examples:
 // - `protocolbuffers/go:v1.36.10` — Official Go protobuf plugin
 // - `grpc/go:v1.5.1` — Official gRPC Go plugin
 // - `grpc-ecosystem/gateway:v2.27.3` — gRPC-Gateway plugin
+// - `org/team/plugin:v1.0.0` — Plugin in a nested group
 //
-// Use `latest` as version to get the most recent version:
+// Use `latest` as version to get the most recent stable version:
 // - `protocolbuffers/go:latest`
+//
+// Use a version constraint to get the most recent stable version in a range:
+// - `protocolbuffers/go:^v1.36` — `>=v1.36.0 <v2.0.0`
+// - `grpc-ecosystem/gateway:~v2.27.0` — `>=v2.27.0 <v2.28.0`
+// - `grpc/go:>=v1.5 <v2` — explicit comparators
+//
+// Versions are ordered by semantic versioning, prereleases are only selected
+// when the constraint names a prerelease explicitly.
+//
+// Use a named channel to follow a release track:
+// - `grpc/go:stable`
+//
+// Pin the exact image content with a digest, optionally together with a version:
+// - `grpc/go@sha256:<hex>`
+// - `grpc/go:v1.5.1@sha256:<hex>`
 type ServiceAPIServer interface {
 	// Generate code using a specified plugin.
 	//
@@ -152,23 +247,64 @@ type ServiceAPIServer interface {
 	// The plugin runs in an isolated Docker container with the following default limits:
 	//
 	// - **Network**: Disabled (no external access)
-	// - **Memory**: 128MB
+	// - **Memory**: 128MB, no swap
 	// - **CPU**: 1.0 core
+	// - **Processes**: 64
+	// - **Capabilities**: All dropped, no new privileges
+	// - **Time**: 1 minute, capped by the server maximum
+	// - **Output**: 64MB of stdout, 10000 files
 	//
 	// ## Error Codes
 	//
 	// | Code | Description |
 	// |------|-------------|
 	// | `NOT_FOUND` | Plugin not found in registry |
-	// | `INVALID_ARGUMENT` | Invalid plugin name format |
-	// | `INTERNAL` | Plugin execution failed |
+	// | `INVALID_ARGUMENT` | Invalid plugin name format, inconsistent `CodeGeneratorRequest`, request size limits exceeded or files use editions or proto3 optional fields the plugin doesn't support (see `google.rpc.BadRequest` details), or the plugin reported an error |
+	// | `FAILED_PRECONDITION` | Requested digest differs from the pinned digest, the plugin is unsigned or mis-signed, its image can't be pulled, or the version is pending or failed its fixtures |
+	// | `INTERNAL` | Plugin exited with a non-zero code or generated an unsafe file name (absolute, escaping the output directory, duplicate) |
 	// | `DEADLINE_EXCEEDED` | Plugin execution timeout |
+	// | `RESOURCE_EXHAUSTED` | Plugin was killed for exceeding its memory limit, or its output exceeds the size or file count limit |
+	// | `UNAVAILABLE` | Container runtime is unavailable, or the plugin circuit breaker is open after consecutive failures; the request may be retried later |
+	//
+	// Plugin execution failures carry `google.rpc.ErrorInfo` details with the failure reason
+	// (`PLUGIN_ERROR`, `PLUGIN_EXIT_CODE`, `PLUGIN_OOM_KILLED`, `PLUGIN_TIMEOUT`, `PLUGIN_IMAGE_PULL`, `DAEMON_UNAVAILABLE`),
+	// plugin, exit code and duration in metadata, and `google.rpc.DebugInfo` details with the beginning of the plugin stderr.
 	GenerateCode(context.Context, *GenerateCodeRequest) (*GenerateCodeResponse, error)
+	// Compare code generated by two plugin versions.
+	//
+	// Runs the same `CodeGeneratorRequest` against both plugins, the way `GenerateCode` does,
+	// and compares the generated files after applying insertion points. Use it to preview
+	// what upgrading a plugin changes in the generated code before bumping the version.
+	//
+	// ## Error Codes
+	//
+	// Errors of either plugin are returned as in `GenerateCode`, the message names the plugin.
+	DiffCode(context.Context, *DiffCodeRequest) (*DiffCodeResponse, error)
 	// List available plugins.
 	//
 	// Returns a list of all plugins registered in the service.
 	// Use this to discover available plugins and their versions.
 	Plugins(context.Context, *PluginsRequest) (*PluginsResponse, error)
+	// Show the effective configuration of a plugin.
+	//
+	// Plugin configuration is merged from layers, later layers override earlier ones:
+	//
+	// 1. Server defaults
+	// 2. Group configuration
+	// 3. Plugin configuration, shared by all versions
+	// 4. Version configuration
+	//
+	// Objects are merged recursively, other values replace the previous ones,
+	// `null` resets a field to its default. Secure defaults are applied to omitted fields.
+	//
+	// ## Error Codes
+	//
+	// | Code | Description |
+	// |------|-------------|
+	// | `NOT_FOUND` | Plugin not found in registry |
+	// | `INVALID_ARGUMENT` | Invalid plugin name format |
+	// | `FAILED_PRECONDITION` | The merged configuration is invalid |
+	PluginConfig(context.Context, *PluginConfigRequest) (*PluginConfigResponse, error)
 }
 
 // UnimplementedServiceAPIServer should be embedded to have
@@ -181,9 +317,15 @@ type UnimplementedServiceAPIServer struct{}
 func (UnimplementedServiceAPIServer) GenerateCode(context.Context, *GenerateCodeRequest) (*GenerateCodeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GenerateCode not implemented")
 }
+func (UnimplementedServiceAPIServer) DiffCode(context.Context, *DiffCodeRequest) (*DiffCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffCode not implemented")
+}
 func (UnimplementedServiceAPIServer) Plugins(context.Context, *PluginsRequest) (*PluginsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Plugins not implemented")
 }
+func (UnimplementedServiceAPIServer) PluginConfig(context.Context, *PluginConfigRequest) (*PluginConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PluginConfig not implemented")
+}
 func (UnimplementedServiceAPIServer) testEmbeddedByValue() {}
 
 // UnsafeServiceAPIServer may be embedded to opt out of forward compatibility for this service.
@@ -222,6 +364,24 @@ func _ServiceAPI_GenerateCode_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ServiceAPI_DiffCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceAPIServer).DiffCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServiceAPI_DiffCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceAPIServer).DiffCode(ctx, req.(*DiffCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ServiceAPI_Plugins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PluginsRequest)
 	if err := dec(in); err != nil {
@@ -240,6 +400,24 @@ func _ServiceAPI_Plugins_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ServiceAPI_PluginConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PluginConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceAPIServer).PluginConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServiceAPI_PluginConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceAPIServer).PluginConfig(ctx, req.(*PluginConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ServiceAPI_ServiceDesc is the grpc.ServiceDesc for ServiceAPI service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -251,10 +429,18 @@ var ServiceAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GenerateCode",
 			Handler:    _ServiceAPI_GenerateCode_Handler,
 		},
+		{
+			MethodName: "DiffCode",
+			Handler:    _ServiceAPI_DiffCode_Handler,
+		},
 		{
 			MethodName: "Plugins",
 			Handler:    _ServiceAPI_Plugins_Handler,
 		},
+		{
+			MethodName: "PluginConfig",
+			Handler:    _ServiceAPI_PluginConfig_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/generator/v1/generator.proto",
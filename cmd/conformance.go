@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sipki-tech/dev-platform/database/connectors"
+	"github.com/sipki-tech/dev-platform/logger"
+
+	"github.com/easyp-tech/service/internal/adapters/registry"
+	"github.com/easyp-tech/service/internal/core"
+)
+
+var (
+	errConformanceUsage = errors.New("usage: conformance <group>/<name>{:<version>|@<digest>} [plugin-config.json]")
+	errNotConformant    = errors.New("plugin is not conformant")
+)
+
+// conformance checks that a plugin image, registered or not, behaves like a well-formed protoc plugin.
+// It runs the plugin the same way the server does, with the optional configuration file as the version layer.
+func conformance(ctx context.Context, cfg config, reg *prometheus.Registry, namespace string, args []string) error {
+	if len(args) == 0 || len(args) > 2 {
+		return errConformanceUsage
+	}
+
+	ref, err := core.ParsePluginRef(args[0])
+	if err != nil {
+		return fmt.Errorf("core.ParsePluginRef: %w", err)
+	}
+	// The image isn't registered, so there is nothing to resolve a constraint or a channel against.
+	if ref.Version != "" && !ref.IsExactVersion() {
+		return fmt.Errorf("%w: exact version or digest is required: %s", core.ErrInvalidPluginName, args[0])
+	}
+
+	var pluginConfig json.RawMessage
+	if len(args) == 2 {
+		pluginConfig, err = os.ReadFile(args[1])
+		if err != nil {
+			return fmt.Errorf("os.ReadFile: %w", err)
+		}
+	}
+
+	r, err := registry.New(ctx, reg, namespace, registry.Config{
+		Postgres: connectors.Raw{
+			Query: cfg.DB.Postgres,
+		},
+		MigrateDir: cfg.DB.MigrateDir,
+		Driver:     cfg.DB.Driver,
+		Domain:     cfg.Registry.Domain,
+		Sandbox:    sandboxPolicy(cfg.Registry.Sandbox),
+		MaxTimeout: cfg.Registry.MaxTimeout,
+		Defaults:   json.RawMessage(cfg.Registry.Defaults),
+	})
+	if err != nil {
+		return fmt.Errorf("registry.New: %w", err)
+	}
+	defer func() {
+		err := r.Close()
+		if err != nil {
+			logger.FromContext(ctx).Error("close database connection", slog.String(logger.Error.String(), err.Error()))
+		}
+	}()
+
+	plugin, err := r.Candidate(ctx, ref, pluginConfig)
+	if err != nil {
+		return fmt.Errorf("r.Candidate: %w", err)
+	}
+
+	results := core.CheckConformance(ctx, plugin)
+	for _, result := range results {
+		status := "PASS"
+		switch {
+		case result.Passed:
+		case result.Advisory:
+			status = "WARN"
+		default:
+			status = "FAIL"
+		}
+
+		if result.Message == "" {
+			fmt.Fprintf(os.Stdout, "%s %s\n", status, result.Check)
+			continue
+		}
+
+		fmt.Fprintf(os.Stdout, "%s %s: %s\n", status, result.Check, result.Message)
+	}
+
+	if !core.Conformant(results) {
+		return fmt.Errorf("%w: %s", errNotConformant, ref)
+	}
+
+	return nil
+}
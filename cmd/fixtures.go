@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sipki-tech/dev-platform/database/connectors"
+	"github.com/sipki-tech/dev-platform/logger"
+
+	adapter_metrics "github.com/easyp-tech/service/internal/adapters/metrics"
+	"github.com/easyp-tech/service/internal/adapters/registry"
+	"github.com/easyp-tech/service/internal/adapters/signature"
+	"github.com/easyp-tech/service/internal/core"
+)
+
+var errFixturesFailed = errors.New("plugin fixtures failed")
+
+// fixtures runs fixtures of the given plugin versions ("<group>/<name>:<version>"),
+// or of every registered version, in the sandbox and prints the results.
+// Versions are marked available or failed by the results.
+func fixtures(ctx context.Context, cfg config, reg *prometheus.Registry, namespace string, plugins []string) error {
+	r, err := registry.New(ctx, reg, namespace, registry.Config{
+		Postgres: connectors.Raw{
+			Query: cfg.DB.Postgres,
+		},
+		MigrateDir: cfg.DB.MigrateDir,
+		Driver:     cfg.DB.Driver,
+		Domain:     cfg.Registry.Domain,
+		Sandbox:    sandboxPolicy(cfg.Registry.Sandbox),
+		MaxTimeout: cfg.Registry.MaxTimeout,
+		Defaults:   json.RawMessage(cfg.Registry.Defaults),
+	})
+	if err != nil {
+		return fmt.Errorf("registry.New: %w", err)
+	}
+	defer func() {
+		err := r.Close()
+		if err != nil {
+			logger.FromContext(ctx).Error("close database connection", slog.String(logger.Error.String(), err.Error()))
+		}
+	}()
+
+	verifier, err := signature.New(cfg.Signature.PublicKeys)
+	if err != nil {
+		return fmt.Errorf("signature.New: %w", err)
+	}
+
+	module := core.New(adapter_metrics.New(reg, namespace), r, verifier, coreLimits(cfg.Limits), core.BreakerConfig{})
+
+	filters := []core.PluginFilter{{}}
+	if len(plugins) > 0 {
+		filters = make([]core.PluginFilter, 0, len(plugins))
+		for _, plugin := range plugins {
+			ref, err := core.ParsePluginRef(plugin)
+			if err != nil {
+				return fmt.Errorf("core.ParsePluginRef: %w", err)
+			}
+			if ref.Version == "" {
+				return fmt.Errorf("%w: version is required: %s", core.ErrInvalidPluginName, plugin)
+			}
+
+			filters = append(filters, core.PluginFilter{Group: ref.Group, Name: ref.Name, Version: ref.Version})
+		}
+	}
+
+	failed := 0
+	for _, filter := range filters {
+		infos, err := r.List(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("r.List: %w", err)
+		}
+
+		if len(infos) == 0 && filter != (core.PluginFilter{}) {
+			return fmt.Errorf("%w: %s/%s:%s", core.ErrNotFound, filter.Group, filter.Name, filter.Version)
+		}
+
+		for _, info := range infos {
+			results, err := module.RunFixtures(ctx, info)
+			if err != nil {
+				return fmt.Errorf("module.RunFixtures: %w", err)
+			}
+
+			for _, result := range results {
+				if result.Passed {
+					fmt.Fprintf(os.Stdout, "PASS %s %s\n", pluginName(info), result.Name)
+					continue
+				}
+
+				failed++
+				fmt.Fprintf(os.Stdout, "FAIL %s %s: %s\n", pluginName(info), result.Name, result.Error)
+			}
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%w: %d", errFixturesFailed, failed)
+	}
+
+	return nil
+}
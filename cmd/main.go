@@ -2,12 +2,15 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
@@ -26,6 +29,7 @@ import (
 
 	adapter_metrics "github.com/easyp-tech/service/internal/adapters/metrics"
 	"github.com/easyp-tech/service/internal/adapters/registry"
+	"github.com/easyp-tech/service/internal/adapters/signature"
 	"github.com/easyp-tech/service/internal/api"
 	"github.com/easyp-tech/service/internal/core"
 	"github.com/easyp-tech/service/internal/flags"
@@ -38,19 +42,28 @@ const (
 
 type (
 	config struct {
-		Server   server         `yaml:"server" env:", prefix=SERVER_"`
-		DB       dbConfig       `yaml:"db" env:", prefix=DB_"`
-		Registry registryConfig `yaml:"registry" env:", prefix=REGISTRY_"`
+		Server       server             `yaml:"server" env:", prefix=SERVER_"`
+		DB           dbConfig           `yaml:"db" env:", prefix=DB_"`
+		Registry     registryConfig     `yaml:"registry" env:", prefix=REGISTRY_"`
+		Signature    signatureConfig    `yaml:"signature" env:", prefix=SIGNATURE_"`
+		Limits       limitsConfig       `yaml:"limits" env:", prefix=LIMITS_"`
+		Breaker      breakerConfig      `yaml:"breaker" env:", prefix=BREAKER_"`
+		Prober       proberConfig       `yaml:"prober" env:", prefix=PROBER_"`
+		Registration registrationConfig `yaml:"registration" env:", prefix=REGISTRATION_"`
 	}
 	server struct {
 		Host string `yaml:"host" env:"HOST, default=0.0.0.0"`
 		Port ports  `yaml:"port" env:", prefix=PORT_"`
+		// AdminToken authenticates administration API calls, the API isn't served without it.
+		AdminToken string `yaml:"admin_token" env:"ADMIN_TOKEN"`
 	}
 	ports struct {
 		GRPC    uint16 `yaml:"grpc" env:"GRPC, default=23410"`
 		Metric  uint16 `yaml:"metric" env:"METRIC, default=23411"`
 		Health  uint16 `yaml:"health" env:"HEALTH, default=23412"`
 		Gateway uint16 `yaml:"gateway" env:"GATEWAY, default=23413"`
+		// Admin serves the administration API, it must not be exposed to plugin users.
+		Admin uint16 `yaml:"admin" env:"ADMIN, default=23414"`
 	}
 	dbConfig struct {
 		MigrateDir string `yaml:"migrate_dir" env:"MIGRATE_DIR, default=migrate"`
@@ -58,8 +71,73 @@ type (
 		Postgres   string `yaml:"postgres" env:"POSTGRES_DSN"`
 	}
 	registryConfig struct {
-		Domain string `yaml:"domain" env:"DOMAIN, default=localhost:5005"`
+		Domain              string        `yaml:"domain" env:"DOMAIN, default=localhost:5005"`
+		DigestCheckInterval time.Duration `yaml:"digest_check_interval" env:"DIGEST_CHECK_INTERVAL, default=10m"`
+		Sandbox             sandboxConfig `yaml:"sandbox" env:", prefix=SANDBOX_"`
+		// MaxTimeout caps plugin execution timeouts set in plugin configuration.
+		MaxTimeout time.Duration `yaml:"max_timeout" env:"MAX_TIMEOUT, default=5m"`
+		// Defaults is a JSON plugin configuration applied to every plugin,
+		// groups, plugins and versions override it.
+		Defaults string `yaml:"defaults" env:"DEFAULTS"`
+		// CgroupRoot is where the cgroup hierarchy of the docker host is mounted,
+		// it is read to record the memory usage peak of plugins after OOM escalation.
+		CgroupRoot string `yaml:"cgroup_root" env:"CGROUP_ROOT, default=/sys/fs/cgroup"`
 	}
+	sandboxConfig struct {
+		AllowPrivileged    bool   `yaml:"allow_privileged" env:"ALLOW_PRIVILEGED, default=false"`
+		AllowNetwork       bool   `yaml:"allow_network" env:"ALLOW_NETWORK, default=false"`
+		AllowHostNetwork   bool   `yaml:"allow_host_network" env:"ALLOW_HOST_NETWORK, default=false"`
+		AllowUnconfined    bool   `yaml:"allow_unconfined" env:"ALLOW_UNCONFINED, default=false"`
+		AllowUnlimitedSwap bool   `yaml:"allow_unlimited_swap" env:"ALLOW_UNLIMITED_SWAP, default=false"`
+		DefaultRuntime     string `yaml:"default_runtime" env:"DEFAULT_RUNTIME"`
+	}
+	signatureConfig struct {
+		// PublicKeys are paths to PEM files with ed25519 or ECDSA public keys.
+		// If set, only plugins signed with one of the keys are executed.
+		PublicKeys []string `yaml:"public_keys" env:"PUBLIC_KEYS"`
+	}
+	limitsConfig struct {
+		// MaxRequestBytes can't exceed grpcMaxRecvMsgSize, larger messages are rejected by gRPC first.
+		MaxRequestBytes    int `yaml:"max_request_bytes" env:"MAX_REQUEST_BYTES, default=4194304"`
+		MaxProtoFiles      int `yaml:"max_proto_files" env:"MAX_PROTO_FILES, default=10000"`
+		MaxFilesToGenerate int `yaml:"max_files_to_generate" env:"MAX_FILES_TO_GENERATE, default=10000"`
+		MaxParameterLength int `yaml:"max_parameter_length" env:"MAX_PARAMETER_LENGTH, default=4096"`
+		// Plugins overrides the limits for heavy plugins, the key is "<group>/<name>".
+		Plugins pluginLimits `yaml:"plugins" env:"PLUGINS"`
+	}
+	inputLimits struct {
+		MaxRequestBytes    int `yaml:"max_request_bytes" json:"max_request_bytes"`
+		MaxProtoFiles      int `yaml:"max_proto_files" json:"max_proto_files"`
+		MaxFilesToGenerate int `yaml:"max_files_to_generate" json:"max_files_to_generate"`
+		MaxParameterLength int `yaml:"max_parameter_length" json:"max_parameter_length"`
+	}
+	breakerConfig struct {
+		// FailureThreshold is the number of consecutive failures which opens a plugin circuit, 0 disables breakers.
+		FailureThreshold int           `yaml:"failure_threshold" env:"FAILURE_THRESHOLD, default=5"`
+		OpenTimeout      time.Duration `yaml:"open_timeout" env:"OPEN_TIMEOUT, default=30s"`
+	}
+	proberConfig struct {
+		// Interval between canary probing rounds, 0 disables probing. Every probed version runs a container
+		// each round, so probing is opt-in and should be limited to the versions in use with Plugins.
+		Interval time.Duration `yaml:"interval" env:"INTERVAL, default=0"`
+		Timeout  time.Duration `yaml:"timeout" env:"TIMEOUT, default=30s"`
+		// Plugins limits probing to "<group>/<name>" or "<group>/<name>:<version>" entries, empty means all.
+		Plugins []string `yaml:"plugins" env:"PLUGINS"`
+	}
+	registrationConfig struct {
+		// Interval between processing rounds of new plugin versions (capabilities and fixtures), 0 disables them.
+		Interval time.Duration `yaml:"interval" env:"INTERVAL, default=1m"`
+	}
+	// pluginLimits is a JSON object in the environment.
+	pluginLimits map[string]inputLimits
+)
+
+// grpcMaxRecvMsgSize is the gRPC default limit of a received message, the servers keep it.
+const grpcMaxRecvMsgSize = 4 << 20
+
+var (
+	errUnknownCommand = errors.New("unknown command")
+	errCanaryFailing  = errors.New("canary probes are failing")
 )
 
 var (
@@ -81,7 +159,7 @@ func main() {
 	defer cancel()
 	go forceShutdown(ctx)
 
-	err := start(ctx, cfgFile, appName)
+	err := start(ctx, cfgFile, appName, flag.Args())
 	if err != nil {
 		log.Error("shutdown",
 			slog.String(logger.Error.String(), err.Error()),
@@ -90,7 +168,7 @@ func main() {
 	}
 }
 
-func start(ctx context.Context, cfgFile *flags.File, appName string) error {
+func start(ctx context.Context, cfgFile *flags.File, appName string, args []string) error {
 	cfg := config{}
 
 	if !cfgFile.IsNil() {
@@ -107,7 +185,20 @@ func start(ctx context.Context, cfgFile *flags.File, appName string) error {
 
 	reg := prometheus.NewPedanticRegistry()
 
-	return run(ctx, cfg, reg, appName)
+	if len(args) == 0 {
+		return run(ctx, cfg, reg, appName)
+	}
+
+	switch args[0] {
+	case "validate":
+		return validate(ctx, cfg, reg, appName, args[1:])
+	case "fixtures":
+		return fixtures(ctx, cfg, reg, appName, args[1:])
+	case "conformance":
+		return conformance(ctx, cfg, reg, appName, args[1:])
+	default:
+		return fmt.Errorf("%w: %s", errUnknownCommand, args[0])
+	}
 }
 
 func run(ctx context.Context, cfg config, reg *prometheus.Registry, namespace string) error {
@@ -118,9 +209,14 @@ func run(ctx context.Context, cfg config, reg *prometheus.Registry, namespace st
 		Postgres: connectors.Raw{
 			Query: cfg.DB.Postgres,
 		},
-		MigrateDir: cfg.DB.MigrateDir,
-		Driver:     cfg.DB.Driver,
-		Domain:     cfg.Registry.Domain,
+		MigrateDir:          cfg.DB.MigrateDir,
+		Driver:              cfg.DB.Driver,
+		Domain:              cfg.Registry.Domain,
+		DigestCheckInterval: cfg.Registry.DigestCheckInterval,
+		Sandbox:             sandboxPolicy(cfg.Registry.Sandbox),
+		MaxTimeout:          cfg.Registry.MaxTimeout,
+		Defaults:            json.RawMessage(cfg.Registry.Defaults),
+		CgroupRoot:          cfg.Registry.CgroupRoot,
 	})
 	if err != nil {
 		return fmt.Errorf("repo.New: %w", err)
@@ -133,7 +229,49 @@ func run(ctx context.Context, cfg config, reg *prometheus.Registry, namespace st
 		}
 	}()
 
-	module := core.New(adapter_metrics.New(reg, namespace), r)
+	problems, err := r.ValidateConfigs(ctx)
+	if err != nil {
+		return fmt.Errorf("r.ValidateConfigs: %w", err)
+	}
+
+	for _, problem := range problems {
+		log.Error("invalid plugin configuration",
+			slog.String("plugin", pluginName(problem.Plugin)),
+			slog.String(logger.Error.String(), problem.Err.Error()),
+		)
+	}
+
+	verifier, err := signature.New(cfg.Signature.PublicKeys)
+	if err != nil {
+		return fmt.Errorf("signature.New: %w", err)
+	}
+
+	if !verifier.Enabled() {
+		log.Warn("plugin signature verification is disabled, no public keys configured")
+	}
+
+	for name, limit := range requestLimits(cfg.Limits) {
+		if limit > grpcMaxRecvMsgSize {
+			log.Warn("request limit exceeds the gRPC message size limit, larger requests are rejected by gRPC",
+				slog.String("plugin", name),
+				slog.Int("max_request_bytes", limit),
+				slog.Int("grpc_max_recv_msg_size", grpcMaxRecvMsgSize),
+			)
+		}
+	}
+
+	coreMetrics := adapter_metrics.New(reg, namespace)
+
+	module := core.New(coreMetrics, r, verifier, coreLimits(cfg.Limits), core.BreakerConfig{
+		FailureThreshold: cfg.Breaker.FailureThreshold,
+		OpenTimeout:      cfg.Breaker.OpenTimeout,
+	})
+
+	prober := core.NewProber(coreMetrics, r, verifier, core.ProberConfig{
+		Interval: cfg.Prober.Interval,
+		Timeout:  cfg.Prober.Timeout,
+		Plugins:  cfg.Prober.Plugins,
+	})
 
 	grpcAPI := api.New(ctx, m, module, reg, namespace)
 
@@ -153,18 +291,107 @@ func run(ctx context.Context, cfg config, reg *prometheus.Registry, namespace st
 				Timeout: healthTimeout,
 				Check:   r.Health,
 			},
+			health.Config{
+				Name:      "plugins",
+				Timeout:   healthTimeout,
+				SkipOnErr: true,
+				Check: func(context.Context) error {
+					if open := module.OpenCircuits(); len(open) > 0 {
+						return fmt.Errorf("%w: %s", core.ErrCircuitOpen, strings.Join(open, ", "))
+					}
+
+					return nil
+				},
+			},
+			health.Config{
+				Name:      "canary",
+				Timeout:   healthTimeout,
+				SkipOnErr: true,
+				Check: func(context.Context) error {
+					if failing := prober.Failing(); len(failing) > 0 {
+						return fmt.Errorf("%w: %s", errCanaryFailing, strings.Join(failing, ", "))
+					}
+
+					return nil
+				},
+			},
 		),
 	)
 	if err != nil {
 		return fmt.Errorf("health.New: %w", err)
 	}
 
-	return serve.Start(
-		ctx,
+	services := []func(context.Context) error{
 		serve.Metrics(log.With(slog.String(logger.Module.String(), "metric")), cfg.Server.Host, cfg.Server.Port.Metric, reg),
 		serve.GRPC(log.With(slog.String(logger.Module.String(), "gRPC")), cfg.Server.Host, cfg.Server.Port.GRPC, grpcAPI),
 		serve.HTTP(log.With(slog.String(logger.Module.String(), "health")), cfg.Server.Host, cfg.Server.Port.Health, h.Handler()),
-	)
+		prober.Run,
+		module.RegistrationRunner(cfg.Registration.Interval),
+	}
+
+	if cfg.Server.AdminToken == "" {
+		log.Warn("admin token is not set, the administration API is disabled")
+	} else {
+		adminAPI := api.NewAdmin(ctx, m, module, reg, namespace, cfg.Server.AdminToken)
+		services = append(services,
+			serve.GRPC(log.With(slog.String(logger.Module.String(), "admin")), cfg.Server.Host, cfg.Server.Port.Admin, adminAPI),
+		)
+	}
+
+	return serve.Start(ctx, services...)
+}
+
+func sandboxPolicy(cfg sandboxConfig) registry.SandboxPolicy {
+	return registry.SandboxPolicy{
+		AllowPrivileged:    cfg.AllowPrivileged,
+		AllowNetwork:       cfg.AllowNetwork,
+		AllowHostNetwork:   cfg.AllowHostNetwork,
+		AllowUnconfined:    cfg.AllowUnconfined,
+		AllowUnlimitedSwap: cfg.AllowUnlimitedSwap,
+		DefaultRuntime:     cfg.DefaultRuntime,
+	}
+}
+
+func coreLimits(cfg limitsConfig) core.Limits {
+	limits := core.Limits{
+		Input: core.InputLimits{
+			MaxRequestBytes:    cfg.MaxRequestBytes,
+			MaxProtoFiles:      cfg.MaxProtoFiles,
+			MaxFilesToGenerate: cfg.MaxFilesToGenerate,
+			MaxParameterLength: cfg.MaxParameterLength,
+		},
+		PluginInput: make(map[string]core.InputLimits, len(cfg.Plugins)),
+	}
+
+	for name, override := range cfg.Plugins {
+		limits.PluginInput[name] = core.InputLimits(override)
+	}
+
+	return limits
+}
+
+// requestLimits returns the request size limits by plugin, the server-wide one has an empty key.
+func requestLimits(cfg limitsConfig) map[string]int {
+	limits := map[string]int{"": cfg.MaxRequestBytes}
+	for name, override := range cfg.Plugins {
+		limits[name] = override.MaxRequestBytes
+	}
+
+	return limits
+}
+
+// EnvDecode implements envconfig.Decoder.
+func (l *pluginLimits) EnvDecode(val string) error {
+	if val == "" {
+		return nil
+	}
+
+	err := json.Unmarshal([]byte(val), l)
+	if err != nil {
+		return fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	return nil
 }
 
 func buildLogger(level slog.Level) *slog.Logger {
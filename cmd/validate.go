@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sipki-tech/dev-platform/database/connectors"
+	"github.com/sipki-tech/dev-platform/logger"
+
+	"github.com/easyp-tech/service/internal/adapters/registry"
+	"github.com/easyp-tech/service/internal/core"
+)
+
+var errInvalidConfig = errors.New("invalid plugin configurations found")
+
+// validate checks plugin configurations and prints every problem.
+// With file arguments it checks plugin configuration JSON files before they are written to the database,
+// otherwise it checks all registered plugins.
+func validate(ctx context.Context, cfg config, reg *prometheus.Registry, namespace string, files []string) error {
+	policy := sandboxPolicy(cfg.Registry.Sandbox)
+
+	failed := 0
+
+	if len(files) > 0 {
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("os.ReadFile: %w", err)
+			}
+
+			err = registry.ValidatePluginConfig(data, policy)
+			if err != nil {
+				failed++
+				fmt.Fprintf(os.Stdout, "%s: %s\n", file, err)
+			}
+		}
+	} else {
+		r, err := registry.New(ctx, reg, namespace, registry.Config{
+			Postgres: connectors.Raw{
+				Query: cfg.DB.Postgres,
+			},
+			MigrateDir: cfg.DB.MigrateDir,
+			Driver:     cfg.DB.Driver,
+			Domain:     cfg.Registry.Domain,
+			Sandbox:    policy,
+			Defaults:   json.RawMessage(cfg.Registry.Defaults),
+		})
+		if err != nil {
+			return fmt.Errorf("registry.New: %w", err)
+		}
+		defer func() {
+			err := r.Close()
+			if err != nil {
+				logger.FromContext(ctx).Error("close database connection", slog.String(logger.Error.String(), err.Error()))
+			}
+		}()
+
+		problems, err := r.ValidateConfigs(ctx)
+		if err != nil {
+			return fmt.Errorf("r.ValidateConfigs: %w", err)
+		}
+
+		for _, problem := range problems {
+			failed++
+			fmt.Fprintf(os.Stdout, "%s: %s\n", pluginName(problem.Plugin), problem.Err)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%w: %d", errInvalidConfig, failed)
+	}
+
+	return nil
+}
+
+func pluginName(info core.PluginInfo) string {
+	return info.Group + "/" + info.Name + ":" + info.Version
+}
@@ -14,6 +14,14 @@ var _ core.Metrics = Metrics{}
 // Metrics is the metrics adapter for the EasyP plugin server.
 type Metrics struct {
 	generated *prometheus.CounterVec
+	unsafe    *prometheus.CounterVec
+	retries   *prometheus.CounterVec
+	circuits  *prometheus.GaugeVec
+	probes    *prometheus.GaugeVec
+	latencies *prometheus.GaugeVec
+	checks    *prometheus.CounterVec
+	shadows   *prometheus.CounterVec
+	diverged  *prometheus.CounterVec
 }
 
 // New creates and returns a new Metrics adapter.
@@ -27,9 +35,73 @@ func New(reg *prometheus.Registry, namespace string) *Metrics {
 			},
 			[]string{"plugin"},
 		),
+		unsafe: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "unsafe_plugin_output_total",
+				Help:      "Total number of plugin responses rejected because of unsafe generated file names.",
+			},
+			[]string{"plugin"},
+		),
+		retries: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "plugin_execution_retries_total",
+				Help:      "Total number of plugin executions retried after a transient failure, by plugin and failure reason.",
+			},
+			[]string{"plugin", "reason"},
+		),
+		circuits: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "plugin_circuit_state",
+				Help:      "Circuit breaker state by plugin: 0 closed, 1 half-open, 2 open.",
+			},
+			[]string{"plugin"},
+		),
+		probes: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "plugin_probe_healthy",
+				Help:      "Result of the latest canary probe by plugin: 1 healthy, 0 failing.",
+			},
+			[]string{"plugin"},
+		),
+		latencies: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "plugin_probe_latency_seconds",
+				Help:      "Duration of the latest canary probe by plugin.",
+			},
+			[]string{"plugin"},
+		),
+		checks: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "plugin_determinism_checks_total",
+				Help:      "Total number of determinism checks by plugin and result: deterministic or nondeterministic.",
+			},
+			[]string{"plugin", "result"},
+		),
+		shadows: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "plugin_shadow_runs_total",
+				Help:      "Total number of requests run against a candidate version by plugin, candidate and outcome.",
+			},
+			[]string{"plugin", "candidate", "outcome"},
+		),
+		diverged: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "plugin_shadow_differing_files_total",
+				Help:      "Total number of generated files which differ between a plugin and its candidate version.",
+			},
+			[]string{"plugin", "candidate"},
+		),
 	}
 
-	reg.MustRegister(m.generated)
+	reg.MustRegister(m.generated, m.unsafe, m.retries, m.circuits, m.probes, m.latencies, m.checks, m.shadows, m.diverged)
 
 	return m
 }
@@ -40,3 +112,61 @@ func (m Metrics) GenerateCode(_ context.Context, info core.PluginInfo) error {
 	m.generated.WithLabelValues(plugin).Inc()
 	return nil
 }
+
+// UnsafeOutput implements the core.Metrics interface.
+func (m Metrics) UnsafeOutput(_ context.Context, info core.PluginInfo) error {
+	plugin := info.Group + "/" + info.Name + ":" + info.Version
+	m.unsafe.WithLabelValues(plugin).Inc()
+	return nil
+}
+
+// Retry implements the core.Metrics interface.
+func (m Metrics) Retry(_ context.Context, info core.PluginInfo, failure core.ExecutionFailure) error {
+	plugin := info.Group + "/" + info.Name + ":" + info.Version
+	m.retries.WithLabelValues(plugin, string(failure)).Inc()
+	return nil
+}
+
+// CircuitState implements the core.Metrics interface.
+func (m Metrics) CircuitState(_ context.Context, info core.PluginInfo, state core.CircuitState) error {
+	plugin := info.Group + "/" + info.Name + ":" + info.Version
+	m.circuits.WithLabelValues(plugin).Set(float64(state))
+	return nil
+}
+
+// Probe implements the core.Metrics interface.
+func (m Metrics) Probe(_ context.Context, info core.PluginInfo, status core.ProbeStatus) error {
+	plugin := info.Group + "/" + info.Name + ":" + info.Version
+
+	healthy := 0.0
+	if status.Healthy {
+		healthy = 1
+	}
+
+	m.probes.WithLabelValues(plugin).Set(healthy)
+	m.latencies.WithLabelValues(plugin).Set(status.Latency.Seconds())
+	return nil
+}
+
+// Determinism implements the core.Metrics interface.
+func (m Metrics) Determinism(_ context.Context, info core.PluginInfo, deterministic bool) error {
+	plugin := info.Group + "/" + info.Name + ":" + info.Version
+
+	result := "deterministic"
+	if !deterministic {
+		result = "nondeterministic"
+	}
+
+	m.checks.WithLabelValues(plugin, result).Inc()
+	return nil
+}
+
+// Shadow implements the core.Metrics interface.
+func (m Metrics) Shadow(_ context.Context, info, candidate core.PluginInfo, result core.ShadowResult) error {
+	plugin := info.Group + "/" + info.Name + ":" + info.Version
+	candidateName := candidate.Group + "/" + candidate.Name + ":" + candidate.Version
+
+	m.shadows.WithLabelValues(plugin, candidateName, string(result.Outcome)).Inc()
+	m.diverged.WithLabelValues(plugin, candidateName).Add(float64(len(result.Differing)))
+	return nil
+}
@@ -0,0 +1,55 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+// Candidate returns a plugin which isn't registered yet, e.g. to check it before registration.
+// The configuration is the version layer, group and plugin layers are applied if they exist.
+// The candidate always runs without network access and memory escalation.
+func (r *Registry) Candidate(ctx context.Context, ref core.PluginRef, config json.RawMessage) (core.Plugin, error) {
+	p := &plugin{
+		GroupName:  ref.Group,
+		Name:       ref.Name,
+		Version:    ref.Version,
+		Config:     config,
+		Digest:     ref.Digest,
+		Status:     string(core.PluginPending),
+		domain:     r.domain,
+		digests:    r.digests,
+		sandbox:    r.sandbox,
+		maxTimeout: r.maxTimeout,
+		memory:     r.memory,
+	}
+
+	err := r.sql.NoTx(func(d *sqlx.DB) error {
+		const query = `select coalesce((select config from plugin_groups where group_name = $1), '{}') as group_config,
+	coalesce((select config from plugin_configs where group_name = $1 and name = $2), '{}') as name_config`
+
+		return d.GetContext(ctx, p, query, ref.Group, ref.Name)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("r.sql.NoTx: %w", err)
+	}
+
+	p.mergedConfig, err = r.mergeLayers(p)
+	if err != nil {
+		return nil, fmt.Errorf("r.mergeLayers: %w (plugin: %s)", err, ref)
+	}
+
+	p.pluginConfig, err = ParsePluginConfig(p.mergedConfig)
+	if err != nil {
+		return nil, fmt.Errorf("ParsePluginConfig: %w (plugin: %s)", err, ref)
+	}
+
+	p.pluginConfig.Docker.Network = "none"
+	p.pluginConfig.OOM.Enabled = false
+
+	return p, nil
+}
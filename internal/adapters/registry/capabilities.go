@@ -0,0 +1,34 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+// SaveCapabilities implements core.Registry.
+func (r *Registry) SaveCapabilities(ctx context.Context, pluginID uuid.UUID, capabilities core.Capabilities) error {
+	err := r.sql.NoTx(func(d *sqlx.DB) error {
+		const query = `update plugins set supported_features = $1, minimum_edition = $2, maximum_edition = $3,
+	capabilities_checked_at = $4 where id = $5`
+
+		_, err := d.ExecContext(ctx, query,
+			int64(capabilities.SupportedFeatures),
+			int32(capabilities.MinimumEdition),
+			int32(capabilities.MaximumEdition),
+			capabilities.CheckedAt,
+			pluginID,
+		)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("r.sql.NoTx: %w", err)
+	}
+
+	return nil
+}
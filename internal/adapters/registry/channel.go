@@ -0,0 +1,243 @@
+package registry
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+type (
+	// channel is a channel with the version it points to.
+	channel struct {
+		GroupName string    `db:"group_name"`
+		Name      string    `db:"name"`
+		Channel   string    `db:"channel"`
+		PluginID  uuid.UUID `db:"plugin_id"`
+		Version   string    `db:"version"`
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+
+	// channelMove is an entry of the channel history.
+	channelMove struct {
+		GroupName        string        `db:"group_name"`
+		Name             string        `db:"name"`
+		Channel          string        `db:"channel"`
+		PluginID         uuid.UUID     `db:"plugin_id"`
+		PreviousPluginID uuid.NullUUID `db:"previous_plugin_id"`
+		Version          string        `db:"version"`
+		Previous         string        `db:"previous"`
+		Reason           string        `db:"reason"`
+		MovedAt          time.Time     `db:"moved_at"`
+	}
+)
+
+// MoveChannel implements core.Registry.
+func (r *Registry) MoveChannel(ctx context.Context, target core.PluginInfo, channelName, reason string) (*core.ChannelMove, error) {
+	move := channelMove{
+		GroupName: target.Group,
+		Name:      target.Name,
+		Channel:   channelName,
+		PluginID:  target.ID,
+		Version:   target.Version,
+		Reason:    reason,
+		MovedAt:   time.Now(),
+	}
+
+	err := r.sql.Tx(ctx, nil, func(tx *sqlx.Tx) error {
+		current, err := lockChannel(ctx, tx, move.GroupName, move.Name, move.Channel)
+		if err != nil {
+			return fmt.Errorf("lockChannel: %w", err)
+		}
+
+		return saveMove(ctx, tx, &move, current)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("r.sql.Tx: %w", err)
+	}
+
+	return move.toCore(), nil
+}
+
+// RollbackChannel implements core.Registry.
+func (r *Registry) RollbackChannel(ctx context.Context, group, name, channelName, reason string) (*core.ChannelMove, error) {
+	move := channelMove{
+		GroupName: group,
+		Name:      name,
+		Channel:   channelName,
+		Reason:    reason,
+		MovedAt:   time.Now(),
+	}
+
+	err := r.sql.Tx(ctx, nil, func(tx *sqlx.Tx) error {
+		current, err := lockChannel(ctx, tx, group, name, channelName)
+		if err != nil {
+			return fmt.Errorf("lockChannel: %w", err)
+		}
+
+		if current == nil {
+			return fmt.Errorf("%w: channel %q of %s/%s", core.ErrNotFound, channelName, group, name)
+		}
+
+		previous := struct {
+			PluginID uuid.NullUUID `db:"previous_plugin_id"`
+			Version  string        `db:"version"`
+			Status   string        `db:"status"`
+		}{}
+		const selectPrevious = `select h.previous_plugin_id, coalesce(pp.version, '') as version, coalesce(pp.status, '') as status
+	from plugin_channel_history h left join plugins pp on pp.id = h.previous_plugin_id
+	where h.group_name = $1 and h.name = $2 and h.channel = $3
+	order by h.moved_at desc limit 1 for update of h`
+
+		err = tx.GetContext(ctx, &previous, selectPrevious, group, name, channelName)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+		case err != nil:
+			return fmt.Errorf("tx.GetContext: %w", err)
+		}
+
+		if !previous.PluginID.Valid || previous.Version == "" {
+			return fmt.Errorf("%w: channel %q of %s/%s has no previous version", core.ErrNotFound, channelName, group, name)
+		}
+
+		if core.PluginStatus(previous.Status) != core.PluginAvailable {
+			return fmt.Errorf("%w: %s/%s:%s is %s", core.ErrPluginUnavailable, group, name, previous.Version, previous.Status)
+		}
+
+		move.PluginID = previous.PluginID.UUID
+		move.Version = previous.Version
+		if move.Reason == "" {
+			move.Reason = "rollback from " + current.Version
+		}
+
+		return saveMove(ctx, tx, &move, current)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("r.sql.Tx: %w", err)
+	}
+
+	return move.toCore(), nil
+}
+
+// lockChannel returns the version the channel points to, locked until the end of the transaction,
+// nil if the channel doesn't exist.
+func lockChannel(ctx context.Context, tx *sqlx.Tx, group, name, channelName string) (*channel, error) {
+	current := channel{}
+	const query = `select c.plugin_id, p.version from plugin_channels c join plugins p on p.id = c.plugin_id
+	where c.group_name = $1 and c.name = $2 and c.channel = $3 for update of c`
+
+	err := tx.GetContext(ctx, &current, query, group, name, channelName)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("tx.GetContext: %w", err)
+	}
+
+	return &current, nil
+}
+
+// saveMove points the channel to the version of the move and records it in the channel history,
+// current is the locked version the channel points to, nil if the channel is created.
+func saveMove(ctx context.Context, tx *sqlx.Tx, move *channelMove, current *channel) error {
+	if current != nil {
+		move.PreviousPluginID = uuid.NullUUID{UUID: current.PluginID, Valid: true}
+		move.Previous = current.Version
+	}
+
+	const upsertChannel = `insert into plugin_channels (group_name, name, channel, plugin_id, updated_at)
+	values (:group_name, :name, :channel, :plugin_id, :moved_at)
+	on conflict (group_name, name, channel) do update set plugin_id = excluded.plugin_id, updated_at = excluded.updated_at`
+
+	_, err := tx.NamedExecContext(ctx, upsertChannel, move)
+	if err != nil {
+		return fmt.Errorf("tx.NamedExecContext: %w", err)
+	}
+
+	const insertHistory = `insert into plugin_channel_history
+	(group_name, name, channel, plugin_id, previous_plugin_id, reason, moved_at)
+	values (:group_name, :name, :channel, :plugin_id, :previous_plugin_id, :reason, :moved_at)`
+
+	_, err = tx.NamedExecContext(ctx, insertHistory, move)
+	if err != nil {
+		return fmt.Errorf("tx.NamedExecContext: %w", err)
+	}
+
+	return nil
+}
+
+// Channels implements core.Registry.
+func (r *Registry) Channels(ctx context.Context, group, name string) ([]core.Channel, error) {
+	var rows []channel
+	err := r.sql.NoTx(func(d *sqlx.DB) error {
+		query := `select c.group_name, c.name, c.channel, c.plugin_id, p.version, c.updated_at
+	from plugin_channels c join plugins p on p.id = c.plugin_id`
+		var args []any
+
+		if group != "" || name != "" {
+			query += " where c.group_name = $1 and c.name = $2"
+			args = append(args, group, name)
+		}
+
+		return d.SelectContext(ctx, &rows, query+" order by c.group_name, c.name, c.channel", args...)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("r.sql.NoTx: %w", err)
+	}
+
+	result := make([]core.Channel, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, core.Channel{
+			Group:     row.GroupName,
+			Name:      row.Name,
+			Channel:   row.Channel,
+			PluginID:  row.PluginID,
+			Version:   row.Version,
+			UpdatedAt: row.UpdatedAt,
+		})
+	}
+
+	return result, nil
+}
+
+// ChannelHistory implements core.Registry.
+func (r *Registry) ChannelHistory(ctx context.Context, group, name, channelName string) ([]core.ChannelMove, error) {
+	var rows []channelMove
+	err := r.sql.NoTx(func(d *sqlx.DB) error {
+		const query = `select h.group_name, h.name, h.channel, h.plugin_id, h.previous_plugin_id,
+	p.version, coalesce(pp.version, '') as previous, h.reason, h.moved_at
+	from plugin_channel_history h
+	join plugins p on p.id = h.plugin_id
+	left join plugins pp on pp.id = h.previous_plugin_id
+	where h.group_name = $1 and h.name = $2 and h.channel = $3
+	order by h.moved_at desc`
+
+		return d.SelectContext(ctx, &rows, query, group, name, channelName)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("r.sql.NoTx: %w", err)
+	}
+
+	result := make([]core.ChannelMove, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *row.toCore())
+	}
+
+	return result, nil
+}
+
+func (m channelMove) toCore() *core.ChannelMove {
+	return &core.ChannelMove{
+		Channel:  m.Channel,
+		Version:  m.Version,
+		Previous: m.Previous,
+		Reason:   m.Reason,
+		MovedAt:  m.MovedAt,
+	}
+}
@@ -0,0 +1,374 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+type (
+	// ByteSize is a quantity of bytes encoded as a docker quantity string (e.g., "128m", "1g").
+	// -1 means unlimited where docker allows it.
+	ByteSize int64
+
+	// CPUQuantity is a number of CPUs, encoded as a string or a number (e.g., "1.5").
+	CPUQuantity float64
+)
+
+const (
+	kib = 1 << (10 * (iota + 1))
+	mib
+	gib
+	tib
+)
+
+var (
+	byteSizeRegexp = regexp.MustCompile(`^([0-9]+)([bkmgt]?)b?$`)
+	envKeyRegexp   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	ulimitRegexp   = regexp.MustCompile(`^(-1|[0-9]+)(:(-1|[0-9]+))?$`)
+)
+
+// ParseByteSize parses a docker quantity: a number of bytes with an optional b, k, m, g or t suffix.
+func ParseByteSize(s string) (ByteSize, error) {
+	if s == "-1" {
+		return -1, nil
+	}
+
+	m := byteSizeRegexp.FindStringSubmatch(strings.ToLower(s))
+	if m == nil {
+		return 0, fmt.Errorf("invalid quantity %q", s)
+	}
+
+	n, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("strconv.ParseInt: %w", err)
+	}
+
+	unit := int64(1)
+	switch m[2] {
+	case "k":
+		unit = kib
+	case "m":
+		unit = mib
+	case "g":
+		unit = gib
+	case "t":
+		unit = tib
+	}
+
+	if n > (1<<63-1)/unit {
+		return 0, fmt.Errorf("quantity %q overflows", s)
+	}
+
+	return ByteSize(n * unit), nil
+}
+
+// String formats the size with the largest unit that represents it exactly.
+func (b ByteSize) String() string {
+	if b <= 0 {
+		return strconv.FormatInt(int64(b), 10)
+	}
+
+	for _, u := range []struct {
+		size   int64
+		suffix string
+	}{{tib, "t"}, {gib, "g"}, {mib, "m"}, {kib, "k"}} {
+		if int64(b)%u.size == 0 {
+			return strconv.FormatInt(int64(b)/u.size, 10) + u.suffix
+		}
+	}
+
+	return strconv.FormatInt(int64(b), 10) + "b"
+}
+
+// UnmarshalJSON implements json.Unmarshaler, it accepts quantity strings and numbers of bytes.
+func (b *ByteSize) UnmarshalJSON(data []byte) error {
+	var n int64
+	if err := json.Unmarshal(data, &n); err == nil {
+		*b = ByteSize(n)
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("quantity must be a string or a number: %w", err)
+	}
+
+	size, err := ParseByteSize(s)
+	if err != nil {
+		return err
+	}
+
+	*b = size
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (b ByteSize) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.String())
+}
+
+// String formats the quantity for docker.
+func (c CPUQuantity) String() string {
+	return strconv.FormatFloat(float64(c), 'f', -1, 64)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, it accepts strings and numbers.
+func (c *CPUQuantity) UnmarshalJSON(data []byte) error {
+	var f float64
+	if err := json.Unmarshal(data, &f); err == nil {
+		*c = CPUQuantity(f)
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("cpus must be a string or a number: %w", err)
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("invalid cpus %q", s)
+	}
+
+	*c = CPUQuantity(f)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c CPUQuantity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+// ParsePluginConfig decodes and validates a plugin configuration.
+// Unknown fields are rejected. Missing sections get the default configuration.
+func ParsePluginConfig(data []byte) (PluginConfig, error) {
+	cfg := PluginConfig{}
+
+	if len(bytes.TrimSpace(data)) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+
+		err := decoder.Decode(&cfg)
+		if err != nil {
+			return PluginConfig{}, fmt.Errorf("%w: %w", core.ErrInvalidPluginConfig, err)
+		}
+	}
+
+	if cfg.Docker == nil {
+		cfg.Docker = &DockerConfig{}
+	}
+
+	if cfg.Limits == nil {
+		cfg.Limits = &LimitsConfig{}
+	}
+
+	if cfg.Retry == nil {
+		cfg.Retry = &RetryConfig{}
+	}
+
+	if cfg.OOM == nil {
+		cfg.OOM = &OOMConfig{}
+	}
+
+	if cfg.Determinism == nil {
+		cfg.Determinism = &DeterminismConfig{}
+	}
+
+	if cfg.Shadow == nil {
+		cfg.Shadow = &ShadowConfig{}
+	}
+
+	err := cfg.Validate()
+	if err != nil {
+		return PluginConfig{}, err
+	}
+
+	return cfg, nil
+}
+
+// MergeConfigs merges plugin configuration layers, later layers override earlier ones.
+// Objects are merged recursively, other values replace the previous ones, null resets a field to its default.
+func MergeConfigs(layers ...json.RawMessage) (json.RawMessage, error) {
+	merged := map[string]any{}
+
+	for i, layer := range layers {
+		if len(bytes.TrimSpace(layer)) == 0 {
+			continue
+		}
+
+		var patch map[string]any
+		err := json.Unmarshal(layer, &patch)
+		if err != nil {
+			return nil, fmt.Errorf("%w: layer %d: %w", core.ErrInvalidPluginConfig, i, err)
+		}
+
+		mergeObjects(merged, patch)
+	}
+
+	result, err := json.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	return result, nil
+}
+
+func mergeObjects(dst, patch map[string]any) {
+	for key, value := range patch {
+		if value == nil {
+			delete(dst, key)
+			continue
+		}
+
+		patchObject, ok := value.(map[string]any)
+		if !ok {
+			dst[key] = value
+			continue
+		}
+
+		dstObject, ok := dst[key].(map[string]any)
+		if !ok {
+			dstObject = map[string]any{}
+			dst[key] = dstObject
+		}
+
+		mergeObjects(dstObject, patchObject)
+	}
+}
+
+// ValidatePluginConfig checks that the configuration is valid and allowed by the sandbox policy.
+func ValidatePluginConfig(data []byte, policy SandboxPolicy) error {
+	cfg, err := ParsePluginConfig(data)
+	if err != nil {
+		return fmt.Errorf("ParsePluginConfig: %w", err)
+	}
+
+	err = policy.check(cfg.Docker)
+	if err != nil {
+		return fmt.Errorf("policy.check: %w", err)
+	}
+
+	return nil
+}
+
+// Validate reports every problem of the configuration, the error wraps core.ErrInvalidPluginConfig.
+func (c PluginConfig) Validate() error {
+	var errs []error
+	problem := func(field, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: %s", field, fmt.Sprintf(format, args...)))
+	}
+
+	if d := c.Docker; d != nil {
+		if d.Memory < 0 {
+			problem("docker.memory", "must be positive, got %s", d.Memory)
+		}
+		if d.MemorySwap < -1 || (d.MemorySwap > 0 && d.Memory > 0 && d.MemorySwap < d.Memory) {
+			problem("docker.memory_swap", "must be -1 or not less than memory, got %s", d.MemorySwap)
+		}
+		if d.CPUs < 0 {
+			problem("docker.cpus", "must be positive, got %s", d.CPUs)
+		}
+		if d.PidsLimit < 0 || d.PidsLimit > maxPidsLimit {
+			problem("docker.pids_limit", "must be between 1 and %d, got %d", maxPidsLimit, d.PidsLimit)
+		}
+		if !allowedNetwork(d.Network) {
+			problem("docker.network", "must be none, bridge, host or container:<name>, got %q", d.Network)
+		}
+		if d.WorkingDir != "" && !path.IsAbs(d.WorkingDir) {
+			problem("docker.working_dir", "must be an absolute path, got %q", d.WorkingDir)
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(d.Env)) {
+			if !envKeyRegexp.MatchString(key) {
+				problem("docker.env", "invalid variable name %q", key)
+			}
+		}
+		for _, p := range slices.Sorted(maps.Keys(d.TmpFS)) {
+			if !path.IsAbs(p) {
+				problem("docker.tmpfs", "mount point must be an absolute path, got %q", p)
+			}
+		}
+		for _, name := range slices.Sorted(maps.Keys(d.Ulimits)) {
+			if !ulimitRegexp.MatchString(d.Ulimits[name]) {
+				problem("docker.ulimits", "%s: expected <soft>[:<hard>], got %q", name, d.Ulimits[name])
+			}
+		}
+		for _, rates := range []struct {
+			field  string
+			limits map[string]ByteSize
+		}{{"docker.device_read_bps", d.DeviceReadBPS}, {"docker.device_write_bps", d.DeviceWriteBPS}} {
+			for _, device := range slices.Sorted(maps.Keys(rates.limits)) {
+				if !path.IsAbs(device) {
+					problem(rates.field, "device must be an absolute path, got %q", device)
+				}
+				if rates.limits[device] <= 0 {
+					problem(rates.field, "%s: rate must be positive, got %s", device, rates.limits[device])
+				}
+			}
+		}
+	}
+
+	if l := c.Limits; l != nil {
+		if l.Timeout < 0 {
+			problem("limits.timeout", "must be positive, got %s", l.Timeout)
+		}
+		if l.MaxOutput < 0 {
+			problem("limits.max_output", "must be positive, got %s", l.MaxOutput)
+		}
+		if l.MaxFiles < 0 {
+			problem("limits.max_files", "must be positive, got %d", l.MaxFiles)
+		}
+		if l.MaxStderr < 0 {
+			problem("limits.max_stderr", "must be positive, got %s", l.MaxStderr)
+		}
+	}
+
+	if r := c.Retry; r != nil {
+		if r.MaxAttempts < 0 {
+			problem("retry.max_attempts", "must be positive, got %d", r.MaxAttempts)
+		}
+		if r.InitialBackoff < 0 {
+			problem("retry.initial_backoff", "must be positive, got %s", r.InitialBackoff)
+		}
+		if r.MaxBackoff < 0 {
+			problem("retry.max_backoff", "must be positive, got %s", r.MaxBackoff)
+		}
+	}
+
+	if o := c.OOM; o != nil {
+		if o.Enabled && o.MaxMemory <= 0 {
+			problem("oom_escalation.max_memory", "is required when escalation is enabled")
+		}
+		if o.MaxMemory < 0 {
+			problem("oom_escalation.max_memory", "must be positive, got %s", o.MaxMemory)
+		}
+		if o.Factor != 0 && o.Factor <= 1 {
+			problem("oom_escalation.factor", "must be greater than 1, got %g", o.Factor)
+		}
+	}
+
+	if s := c.Shadow; s != nil {
+		if s.Fraction < 0 || s.Fraction > 1 {
+			problem("shadow.fraction", "must be from 0 to 1, got %g", s.Fraction)
+		}
+		if s.Fraction > 0 && s.Version == "" {
+			problem("shadow.version", "is required when fraction is set")
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", core.ErrInvalidPluginConfig, errors.Join(errs...))
+	}
+
+	return nil
+}
@@ -0,0 +1,245 @@
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+func TestParseByteSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in      string
+		want    ByteSize
+		wantErr bool
+	}{
+		{in: "0", want: 0},
+		{in: "512", want: 512},
+		{in: "512b", want: 512},
+		{in: "64k", want: 64 * kib},
+		{in: "64kb", want: 64 * kib},
+		{in: "128m", want: 128 * mib},
+		{in: "128M", want: 128 * mib},
+		{in: "10mb", want: 10 * mib},
+		{in: "2g", want: 2 * gib},
+		{in: "1t", want: tib},
+		{in: "-1", want: -1},
+		{in: "8388607t", want: 8388607 * tib},
+
+		{in: "", wantErr: true},
+		{in: "m", wantErr: true},
+		{in: "1.5g", wantErr: true},
+		{in: "-2", wantErr: true},
+		{in: "-1m", wantErr: true},
+		{in: "10x", wantErr: true},
+		{in: "10 m", wantErr: true},
+		{in: "8388608t", wantErr: true},
+		{in: "9223372036854775808", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseByteSize(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseByteSize(%q) = %d, want an error", tt.in, got)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseByteSize(%q): %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Fatalf("ParseByteSize(%q) = %d, want %d", tt.in, got, tt.want)
+			}
+
+			again, err := ParseByteSize(got.String())
+			if err != nil || again != got {
+				t.Fatalf("ParseByteSize(%q) = %d, %v; want %d", got.String(), again, err, got)
+			}
+		})
+	}
+}
+
+func TestByteSizeString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   ByteSize
+		want string
+	}{
+		{in: -1, want: "-1"},
+		{in: 0, want: "0"},
+		{in: 1000, want: "1000b"},
+		{in: 1536, want: "1536b"},
+		{in: 128 * mib, want: "128m"},
+		{in: 64 * kib, want: "64k"},
+		{in: 1536 * mib, want: "1536m"},
+		{in: 2 * tib, want: "2t"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.in.String(); got != tt.want {
+			t.Errorf("ByteSize(%d).String() = %q, want %q", int64(tt.in), got, tt.want)
+		}
+	}
+}
+
+func TestPluginConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config string
+		// problems are the fields expected in the error, none if the configuration is valid.
+		problems []string
+	}{
+		{name: "empty", config: ``},
+		{name: "defaults", config: `{}`},
+		{name: "limits", config: `{"docker": {"memory": "256m", "memory_swap": "512m", "cpus": 1.5, "pids_limit": 128}}`},
+		{name: "swap equals memory", config: `{"docker": {"memory": "256m", "memory_swap": "256m"}}`},
+		{name: "unlimited swap", config: `{"docker": {"memory": "256m", "memory_swap": "-1"}}`},
+		{name: "unlimited swap number", config: `{"docker": {"memory_swap": -1}}`},
+		{name: "swap without memory", config: `{"docker": {"memory_swap": "64m"}}`},
+		{name: "swap below memory", config: `{"docker": {"memory": "256m", "memory_swap": "128m"}}`, problems: []string{"docker.memory_swap"}},
+		{name: "negative swap", config: `{"docker": {"memory_swap": -2}}`, problems: []string{"docker.memory_swap"}},
+		{name: "negative memory", config: `{"docker": {"memory": -5}}`, problems: []string{"docker.memory"}},
+		{name: "pids limit", config: `{"docker": {"pids_limit": 4097}}`, problems: []string{"docker.pids_limit"}},
+		{name: "network", config: `{"docker": {"network": "my-net"}}`, problems: []string{"docker.network"}},
+		{name: "container network", config: `{"docker": {"network": "container:"}}`, problems: []string{"docker.network"}},
+		{name: "working dir", config: `{"docker": {"working_dir": "tmp"}}`, problems: []string{"docker.working_dir"}},
+		{name: "env", config: `{"docker": {"env": {"1X": "y"}}}`, problems: []string{"docker.env"}},
+		{name: "tmpfs", config: `{"docker": {"tmpfs": {"tmp": ""}}}`, problems: []string{"docker.tmpfs"}},
+		{name: "ulimits", config: `{"docker": {"ulimits": {"nofile": "a:b"}}}`, problems: []string{"docker.ulimits"}},
+		{name: "device rate", config: `{"docker": {"device_read_bps": {"/dev/sda": 0}}}`, problems: []string{"docker.device_read_bps"}},
+		{name: "timeout", config: `{"limits": {"timeout": "-1s"}}`, problems: []string{"limits.timeout"}},
+		{name: "oom without max memory", config: `{"oom_escalation": {"enabled": true}}`, problems: []string{"oom_escalation.max_memory"}},
+		{name: "oom factor", config: `{"oom_escalation": {"enabled": true, "max_memory": "1g", "factor": 1}}`,
+			problems: []string{"oom_escalation.factor"}},
+		{name: "shadow", config: `{"shadow": {"fraction": 0.5}}`, problems: []string{"shadow.version"}},
+		{name: "every problem", config: `{"docker": {"memory": -5, "network": "x"}, "shadow": {"fraction": 2, "version": "v1.0.0"}}`,
+			problems: []string{"docker.memory", "docker.network", "shadow.fraction"}},
+		{name: "unknown field", config: `{"docker": {"memroy": "1g"}}`, problems: []string{"memroy"}},
+		{name: "invalid quantity", config: `{"docker": {"memory": "1.5g"}}`, problems: []string{"1.5g"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParsePluginConfig([]byte(tt.config))
+			if len(tt.problems) == 0 {
+				if err != nil {
+					t.Fatalf("ParsePluginConfig(%s): %v", tt.config, err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, core.ErrInvalidPluginConfig) {
+				t.Fatalf("ParsePluginConfig(%s) = %v, want ErrInvalidPluginConfig", tt.config, err)
+			}
+			for _, problem := range tt.problems {
+				if !strings.Contains(err.Error(), problem) {
+					t.Errorf("ParsePluginConfig(%s) = %v, want a problem with %s", tt.config, err, problem)
+				}
+			}
+		})
+	}
+}
+
+func TestMergeConfigs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		layers []string
+		want   string
+	}{
+		{name: "no layers", want: `{}`},
+		{name: "empty layers", layers: []string{``, ` `, `{}`}, want: `{}`},
+		{name: "null layer", layers: []string{`{"docker": {"user": "nobody"}}`, `null`}, want: `{"docker": {"user": "nobody"}}`},
+		{
+			name:   "objects are merged recursively",
+			layers: []string{`{"docker": {"user": "nobody", "env": {"A": "1"}}}`, `{"docker": {"memory": "1g", "env": {"B": "2"}}}`},
+			want:   `{"docker": {"user": "nobody", "memory": "1g", "env": {"A": "1", "B": "2"}}}`,
+		},
+		{
+			name:   "later layers win",
+			layers: []string{`{"docker": {"memory": "128m"}}`, `{"docker": {"memory": "256m"}}`, `{"docker": {"memory": "512m"}}`},
+			want:   `{"docker": {"memory": "512m"}}`,
+		},
+		{
+			name:   "null resets to the default",
+			layers: []string{`{"docker": {"user": "nobody", "memory": "1g"}}`, `{"docker": {"user": null}}`},
+			want:   `{"docker": {"memory": "1g"}}`,
+		},
+		{
+			name:   "null resets a section",
+			layers: []string{`{"docker": {"user": "nobody"}, "limits": {"timeout": "1m"}}`, `{"docker": null}`},
+			want:   `{"limits": {"timeout": "1m"}}`,
+		},
+		{
+			name:   "arrays replace arrays",
+			layers: []string{`{"docker": {"cap_drop": ["ALL"]}}`, `{"docker": {"cap_drop": ["NET_RAW"]}}`},
+			want:   `{"docker": {"cap_drop": ["NET_RAW"]}}`,
+		},
+		{
+			name:   "an array replaces an object",
+			layers: []string{`{"docker": {"env": {"A": "1"}}}`, `{"docker": {"env": ["A=1"]}}`},
+			want:   `{"docker": {"env": ["A=1"]}}`,
+		},
+		{
+			name:   "an object replaces a scalar",
+			layers: []string{`{"docker": "none"}`, `{"docker": {"network": "none"}}`},
+			want:   `{"docker": {"network": "none"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			layers := make([]json.RawMessage, 0, len(tt.layers))
+			for _, layer := range tt.layers {
+				layers = append(layers, json.RawMessage(layer))
+			}
+
+			got, err := MergeConfigs(layers...)
+			if err != nil {
+				t.Fatalf("MergeConfigs: %v", err)
+			}
+
+			var gotValue, wantValue any
+			if err := json.Unmarshal(got, &gotValue); err != nil {
+				t.Fatalf("json.Unmarshal(%s): %v", got, err)
+			}
+			if err := json.Unmarshal([]byte(tt.want), &wantValue); err != nil {
+				t.Fatalf("json.Unmarshal(%s): %v", tt.want, err)
+			}
+			if !reflect.DeepEqual(gotValue, wantValue) {
+				t.Fatalf("MergeConfigs(%q) = %s, want %s", tt.layers, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeConfigsErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, layer := range []string{`[]`, `"docker"`, `{"docker":`} {
+		_, err := MergeConfigs(json.RawMessage(`{}`), json.RawMessage(layer))
+		if !errors.Is(err, core.ErrInvalidPluginConfig) {
+			t.Errorf("MergeConfigs(%s) = %v, want ErrInvalidPluginConfig", layer, err)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package registry
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+type (
+	// DeterminismConfig enables determinism verification of every request to the plugin.
+	DeterminismConfig struct {
+		// Verify runs the plugin twice per request and compares the responses, disabled by default.
+		Verify bool `json:"verify,omitempty"`
+	}
+
+	// determinism is the summary of determinism checks of a plugin.
+	determinism struct {
+		PluginID           uuid.UUID    `db:"plugin_id"`
+		Checks             int64        `db:"checks"`
+		Nondeterministic   int64        `db:"nondeterministic"`
+		Files              string       `db:"files"` // JSON array, a string keeps lib/pq from sending bytea.
+		CheckedAt          time.Time    `db:"checked_at"`
+		NondeterministicAt sql.NullTime `db:"nondeterministic_at"`
+	}
+)
+
+// VerifyDeterminism implements core.Plugin.
+func (p *plugin) VerifyDeterminism(_ context.Context) bool {
+	return p.pluginConfig.Determinism.Verify
+}
+
+// SaveDeterminism implements core.Registry.
+func (r *Registry) SaveDeterminism(ctx context.Context, pluginID uuid.UUID, check core.DeterminismCheck) error {
+	files, err := json.Marshal(check.Files)
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	row := determinism{
+		PluginID:  pluginID,
+		Checks:    1,
+		Files:     string(files),
+		CheckedAt: check.CheckedAt,
+	}
+	if !check.Deterministic {
+		row.Nondeterministic = 1
+		row.NondeterministicAt = sql.NullTime{Time: check.CheckedAt, Valid: true}
+	}
+
+	err = r.sql.NoTx(func(d *sqlx.DB) error {
+		const query = `insert into plugin_determinism (plugin_id, checks, nondeterministic, files, checked_at, nondeterministic_at)
+	values (:plugin_id, :checks, :nondeterministic, :files, :checked_at, :nondeterministic_at)
+	on conflict (plugin_id) do update set checks = plugin_determinism.checks + excluded.checks,
+	nondeterministic = plugin_determinism.nondeterministic + excluded.nondeterministic,
+	files = case when excluded.nondeterministic > 0 then excluded.files else plugin_determinism.files end,
+	checked_at = excluded.checked_at,
+	nondeterministic_at = coalesce(excluded.nondeterministic_at, plugin_determinism.nondeterministic_at)`
+
+		_, err := d.NamedExecContext(ctx, query, row)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("r.sql.NoTx: %w", err)
+	}
+
+	return nil
+}
+
+// Determinism implements core.Registry.
+func (r *Registry) Determinism(ctx context.Context) (map[uuid.UUID]core.DeterminismStatus, error) {
+	var rows []determinism
+	err := r.sql.NoTx(func(d *sqlx.DB) error {
+		const query = `select plugin_id, checks, nondeterministic, files, checked_at, nondeterministic_at
+	from plugin_determinism`
+
+		return d.SelectContext(ctx, &rows, query)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("r.sql.NoTx: %w", err)
+	}
+
+	result := make(map[uuid.UUID]core.DeterminismStatus, len(rows))
+	for _, row := range rows {
+		var files []string
+		err = json.Unmarshal([]byte(row.Files), &files)
+		if err != nil {
+			return nil, fmt.Errorf("json.Unmarshal: %w", err)
+		}
+
+		result[row.PluginID] = core.DeterminismStatus{
+			Checks:             row.Checks,
+			Nondeterministic:   row.Nondeterministic,
+			Files:              files,
+			CheckedAt:          row.CheckedAt,
+			NondeterministicAt: row.NondeterministicAt.Time,
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,304 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sipki-tech/dev-platform/logger"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+const (
+	defaultDigestCheckInterval = 10 * time.Minute
+	digestCheckTimeout         = 30 * time.Second
+)
+
+// manifestMediaTypes are the manifest types accepted from the registry. Indexes come first,
+// so the digest is the one docker resolves the tag to.
+var manifestMediaTypes = strings.Join([]string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}, ", ")
+
+// challengeParam matches a parameter of a WWW-Authenticate challenge, e.g. realm="https://auth.example.com/token".
+var challengeParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+type (
+	// digestChecker detects tags which were re-pushed after the plugin was pinned to a digest.
+	// The tag of every image is resolved at most once per interval.
+	digestChecker struct {
+		interval time.Duration
+		client   *http.Client
+		mismatch *prometheus.CounterVec
+
+		mu      sync.Mutex
+		checked map[string]tagCheck // Key: image:tag.
+	}
+
+	// tagCheck is the digest the tag pointed to when it was last resolved.
+	tagCheck struct {
+		digest    string
+		checkedAt time.Time
+	}
+)
+
+func newDigestChecker(reg *prometheus.Registry, namespace, subsystem string, interval time.Duration) *digestChecker {
+	if interval <= 0 {
+		interval = defaultDigestCheckInterval
+	}
+
+	c := &digestChecker{
+		interval: interval,
+		client:   &http.Client{Timeout: digestCheckTimeout},
+		mismatch: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      "plugin_digest_mismatch_total",
+				Help:      "Total number of detected mismatches between a plugin tag and its pinned digest.",
+			},
+			[]string{"plugin"},
+		),
+		checked: make(map[string]tagCheck),
+	}
+
+	reg.MustRegister(c.mismatch)
+
+	return c
+}
+
+// verify returns an error wrapping core.ErrDigestMismatch if the tag points to another digest than the pinned one.
+// A tag which can't be resolved is logged and doesn't prevent execution, the pinned content is run.
+func (c *digestChecker) verify(ctx context.Context, repository, tag, digest string) error {
+	image := repository + ":" + tag
+
+	c.mu.Lock()
+	last, ok := c.checked[image]
+	c.mu.Unlock()
+
+	if !ok || time.Since(last.checkedAt) >= c.interval {
+		actual, err := c.resolve(ctx, repository, tag)
+		if err != nil {
+			logger.FromContext(ctx).Warn("failed to resolve plugin tag digest",
+				slog.String("image", image),
+				slog.String(logger.Error.String(), err.Error()),
+			)
+
+			return nil
+		}
+
+		last = tagCheck{digest: actual, checkedAt: time.Now()}
+		c.mu.Lock()
+		c.checked[image] = last
+		c.mu.Unlock()
+	}
+
+	if last.digest != digest {
+		c.mismatch.WithLabelValues(image).Inc()
+
+		return fmt.Errorf("%w: %s points to %s, the plugin is pinned to %s", core.ErrDigestMismatch, image, last.digest, digest)
+	}
+
+	return nil
+}
+
+// resolve returns the content digest the tag currently points to, it asks the registry for the manifest
+// without downloading the image.
+func (c *digestChecker) resolve(ctx context.Context, repository, tag string) (string, error) {
+	host, name, ok := strings.Cut(repository, "/")
+	if !ok {
+		return "", fmt.Errorf("invalid repository %q", repository)
+	}
+
+	manifest := url.URL{
+		Scheme: registryScheme(host),
+		Host:   host,
+		Path:   "/v2/" + name + "/manifests/" + tag,
+	}
+
+	resp, err := c.headManifest(ctx, manifest.String(), "")
+	if err != nil {
+		return "", fmt.Errorf("c.headManifest: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		token, err := c.token(ctx, resp.Header.Get("WWW-Authenticate"))
+		if err != nil {
+			return "", fmt.Errorf("c.token: %w", err)
+		}
+
+		resp, err = c.headManifest(ctx, manifest.String(), token)
+		if err != nil {
+			return "", fmt.Errorf("c.headManifest: %w", err)
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("HEAD %s: %s", manifest.String(), resp.Status)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return "", fmt.Errorf("HEAD %s: no Docker-Content-Digest header", manifest.String())
+	}
+
+	return digest, nil
+}
+
+func (c *digestChecker) headManifest(ctx context.Context, manifest, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, manifest, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("http.NewRequestWithContext: %w", err)
+	}
+
+	req.Header.Set("Accept", manifestMediaTypes)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("c.client.Do: %w", err)
+	}
+	resp.Body.Close()
+
+	return resp, nil
+}
+
+// token requests an anonymous pull token for the Bearer challenge of the registry.
+func (c *digestChecker) token(ctx context.Context, challenge string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("unsupported authentication challenge %q", challenge)
+	}
+
+	query := url.Values{}
+	realm := ""
+	for _, param := range challengeParam.FindAllStringSubmatch(params, -1) {
+		switch param[1] {
+		case "realm":
+			realm = param[2]
+		case "service", "scope":
+			query.Set(param[1], param[2])
+		}
+	}
+
+	if realm == "" {
+		return "", errors.New("no realm in the authentication challenge")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm+"?"+query.Encode(), http.NoBody)
+	if err != nil {
+		return "", fmt.Errorf("http.NewRequestWithContext: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("c.client.Do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("GET %s: %s", realm, resp.Status)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&body)
+	if err != nil {
+		return "", fmt.Errorf("json.Decode: %w", err)
+	}
+
+	if body.Token != "" {
+		return body.Token, nil
+	}
+
+	return body.AccessToken, nil
+}
+
+// registryScheme returns http for registries on the loopback interface, docker doesn't require TLS for them,
+// and https otherwise.
+func registryScheme(host string) string {
+	hostname := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		hostname = h
+	}
+
+	if hostname == "localhost" {
+		return "http"
+	}
+
+	if ip := net.ParseIP(hostname); ip != nil && ip.IsLoopback() {
+		return "http"
+	}
+
+	return "https"
+}
+
+// PinDigests implements core.Registry.
+func (r *Registry) PinDigests(ctx context.Context) ([]core.PluginInfo, error) {
+	var plugins []plugin
+	err := r.sql.NoTx(func(d *sqlx.DB) error {
+		const query = `select id, group_name, name, version, digest, created_at, status,
+	supported_features, minimum_edition, maximum_edition, capabilities_checked_at from plugins where digest = ''`
+
+		return d.SelectContext(ctx, &plugins, query)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("r.sql.NoTx: %w", err)
+	}
+
+	log := logger.FromContext(ctx)
+	var pinned []core.PluginInfo
+	for _, p := range plugins {
+		repository := r.domain.String() + "/" + p.GroupName + "/" + p.Name
+
+		digest, err := r.digests.resolve(ctx, repository, p.Version)
+		if err != nil {
+			if ctx.Err() != nil {
+				return pinned, ctx.Err()
+			}
+
+			log.Warn("failed to resolve plugin tag digest",
+				slog.String("image", repository+":"+p.Version),
+				slog.String(logger.Error.String(), err.Error()),
+			)
+
+			continue
+		}
+
+		err = r.sql.NoTx(func(d *sqlx.DB) error {
+			// Keep a digest set concurrently, e.g. by an operator.
+			const query = `update plugins set digest = $2 where id = $1 and digest = ''`
+			_, err := d.ExecContext(ctx, query, p.ID, digest)
+
+			return err
+		})
+		if err != nil {
+			return pinned, fmt.Errorf("r.sql.NoTx: %w", err)
+		}
+
+		p.Digest = digest
+		pinned = append(pinned, *p.Info(ctx))
+	}
+
+	return pinned, nil
+}
@@ -0,0 +1,124 @@
+package registry
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+const (
+	// dockerRunExitCode is the exit code of "docker run" when the container couldn't be started.
+	dockerRunExitCode = 125
+	// oomExitCode is the exit code of a container killed with SIGKILL, which is how the kernel stops
+	// a container exceeding its memory limit.
+	oomExitCode = 137
+
+	defaultMaxAttempts    = 3
+	defaultInitialBackoff = 200 * time.Millisecond
+	defaultMaxBackoff     = 2 * time.Second
+)
+
+// RetryConfig controls retries of transient execution failures: unreachable daemon,
+// failed pulls caused by the network or container start races. Plugin failures are never retried.
+type RetryConfig struct {
+	// MaxAttempts is the maximum number of executions, defaults to 3, 1 disables retries.
+	MaxAttempts int `json:"max_attempts,omitempty"`
+	// InitialBackoff is the delay before the first retry, defaults to 200ms, it doubles with every retry.
+	InitialBackoff Duration `json:"initial_backoff,omitempty"`
+	// MaxBackoff caps the delay between retries, defaults to 2s.
+	MaxBackoff Duration `json:"max_backoff,omitempty"`
+}
+
+var (
+	daemonUnavailableMarkers = []string{
+		"Cannot connect to the Docker daemon",
+		"error during connect",
+	}
+	imagePullMarkers = []string{
+		"Unable to find image",
+		"pull access denied",
+		"manifest unknown",
+		"failed to resolve reference",
+		"repository does not exist",
+	}
+	transientMarkers = []string{
+		"timeout",
+		"connection reset",
+		"connection refused",
+		"TLS handshake",
+		"toomanyrequests",
+		"503 Service Unavailable",
+		"is already in use",
+	}
+)
+
+// withDefaults returns a copy of the configuration with defaults for omitted fields.
+func (retry RetryConfig) withDefaults() RetryConfig {
+	if retry.MaxAttempts == 0 {
+		retry.MaxAttempts = defaultMaxAttempts
+	}
+
+	if retry.InitialBackoff == 0 {
+		retry.InitialBackoff = Duration(defaultInitialBackoff)
+	}
+
+	if retry.MaxBackoff == 0 {
+		retry.MaxBackoff = Duration(defaultMaxBackoff)
+	}
+
+	return retry
+}
+
+// RetryPolicy implements core.Plugin.
+func (p *plugin) RetryPolicy(_ context.Context) core.RetryPolicy {
+	retry := p.pluginConfig.Retry.withDefaults()
+
+	return core.RetryPolicy{
+		MaxAttempts:    retry.MaxAttempts,
+		InitialBackoff: time.Duration(retry.InitialBackoff),
+		MaxBackoff:     time.Duration(retry.MaxBackoff),
+	}
+}
+
+// classifyExit returns the kind of failure for a non-zero exit code of "docker run".
+// Stderr is only matched against docker messages if docker failed to start the container,
+// otherwise it is the plugin's output.
+func classifyExit(exitCode int, stderr string) core.ExecutionFailure {
+	switch {
+	case exitCode == dockerRunExitCode && containsAny(stderr, daemonUnavailableMarkers):
+		return core.FailureDaemonUnavailable
+	case exitCode == dockerRunExitCode && containsAny(stderr, imagePullMarkers):
+		return core.FailureImagePull
+	case exitCode == oomExitCode:
+		return core.FailureOOMKilled
+	default:
+		return core.FailureExitCode
+	}
+}
+
+// isTransient reports whether the failure is caused by the infrastructure and may be retried.
+func isTransient(failure core.ExecutionFailure, exitCode int, stderr string) bool {
+	switch failure {
+	case core.FailureDaemonUnavailable:
+		return true
+	case core.FailureImagePull:
+		return containsAny(stderr, transientMarkers)
+	case core.FailureExitCode:
+		// The container didn't start, the plugin never ran.
+		return exitCode == dockerRunExitCode && containsAny(stderr, transientMarkers)
+	default:
+		return false
+	}
+}
+
+func containsAny(s string, substrs []string) bool {
+	for _, substr := range substrs {
+		if strings.Contains(s, substr) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,117 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jmoiron/sqlx"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+type (
+	// fixture is a test case of a plugin version.
+	fixture struct {
+		ID       uuid.UUID       `db:"id"`
+		Name     string          `db:"name"`
+		Request  []byte          `db:"request"` // Serialized CodeGeneratorRequest.
+		Expected json.RawMessage `db:"expected"`
+	}
+
+	// expectedFile is the JSON format of an expected generated file.
+	expectedFile struct {
+		Content string `json:"content,omitempty"`
+		SHA256  string `json:"sha256,omitempty"`
+	}
+
+	// fixtureResult is the latest run of a fixture.
+	fixtureResult struct {
+		ID     uuid.UUID `db:"id"`
+		Passed bool      `db:"passed"`
+		Error  string    `db:"error"`
+		RunAt  time.Time `db:"run_at"`
+	}
+)
+
+// Fixtures implements core.Registry.
+func (r *Registry) Fixtures(ctx context.Context, pluginID uuid.UUID) ([]core.Fixture, error) {
+	var fixtures []fixture
+	err := r.sql.NoTx(func(d *sqlx.DB) error {
+		const query = "select id, name, request, expected from plugin_fixtures where plugin_id = $1 order by name"
+
+		return d.SelectContext(ctx, &fixtures, query, pluginID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("r.sql.NoTx: %w", err)
+	}
+
+	result := make([]core.Fixture, 0, len(fixtures))
+	for _, f := range fixtures {
+		req := &pluginpb.CodeGeneratorRequest{}
+		err = proto.Unmarshal(f.Request, req)
+		if err != nil {
+			return nil, fmt.Errorf("proto.Unmarshal: %w (fixture: %s)", err, f.Name)
+		}
+
+		var files map[string]expectedFile
+		err = json.Unmarshal(f.Expected, &files)
+		if err != nil {
+			return nil, fmt.Errorf("json.Unmarshal: %w (fixture: %s)", err, f.Name)
+		}
+
+		expected := make(map[string]core.ExpectedFile, len(files))
+		for name, file := range files {
+			expected[name] = core.ExpectedFile{Content: file.Content, SHA256: file.SHA256}
+		}
+
+		result = append(result, core.Fixture{
+			ID:       f.ID,
+			Name:     f.Name,
+			Request:  req,
+			Expected: expected,
+		})
+	}
+
+	return result, nil
+}
+
+// SaveFixtureResults implements core.Registry.
+func (r *Registry) SaveFixtureResults(
+	ctx context.Context,
+	pluginID uuid.UUID,
+	results []core.FixtureResult,
+	status core.PluginStatus,
+) error {
+	err := r.sql.Tx(ctx, nil, func(tx *sqlx.Tx) error {
+		const updateFixture = "update plugin_fixtures set passed = :passed, error = :error, run_at = :run_at where id = :id"
+		for _, result := range results {
+			_, err := tx.NamedExecContext(ctx, updateFixture, fixtureResult{
+				ID:     result.FixtureID,
+				Passed: result.Passed,
+				Error:  result.Error,
+				RunAt:  result.RunAt,
+			})
+			if err != nil {
+				return fmt.Errorf("tx.NamedExecContext: %w", err)
+			}
+		}
+
+		const updatePlugin = "update plugins set status = $1 where id = $2"
+		_, err := tx.ExecContext(ctx, updatePlugin, string(status), pluginID)
+		if err != nil {
+			return fmt.Errorf("tx.ExecContext: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("r.sql.Tx: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+const (
+	defaultTimeout    = time.Minute
+	defaultMaxTimeout = 5 * time.Minute
+	defaultMaxOutput  = 64 * mib
+	defaultMaxFiles   = 10000
+	defaultMaxStderr  = 64 * kib
+
+	// killDelay is how long a cancelled plugin may take to stop before it is killed.
+	killDelay = 5 * time.Second
+)
+
+type (
+	// Duration is a time.Duration encoded as a Go duration string (e.g., "30s", "2m").
+	Duration time.Duration
+
+	// LimitsConfig limits plugin execution.
+	LimitsConfig struct {
+		// Timeout is the maximum execution time, defaults to 1m and is capped by the server maximum.
+		Timeout Duration `json:"timeout,omitempty"`
+		// MaxOutput is the maximum size of the plugin stdout, defaults to 64m.
+		MaxOutput ByteSize `json:"max_output,omitempty"`
+		// MaxFiles is the maximum number of generated files, defaults to 10000.
+		MaxFiles int `json:"max_files,omitempty"`
+		// MaxStderr is how much of the plugin stderr is kept for error messages, defaults to 64k.
+		MaxStderr ByteSize `json:"max_stderr,omitempty"`
+	}
+)
+
+// String formats the duration as a Go duration string.
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string: %w", err)
+	}
+
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("time.ParseDuration: %w", err)
+	}
+
+	*d = Duration(parsed)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// withDefaults returns a copy of the limits with defaults for omitted fields.
+// The timeout is capped by maxTimeout.
+func (limits LimitsConfig) withDefaults(maxTimeout time.Duration) LimitsConfig {
+	if maxTimeout <= 0 {
+		maxTimeout = defaultMaxTimeout
+	}
+
+	if limits.Timeout == 0 {
+		limits.Timeout = Duration(defaultTimeout)
+	}
+
+	if time.Duration(limits.Timeout) > maxTimeout {
+		limits.Timeout = Duration(maxTimeout)
+	}
+
+	if limits.MaxOutput == 0 {
+		limits.MaxOutput = defaultMaxOutput
+	}
+
+	if limits.MaxFiles == 0 {
+		limits.MaxFiles = defaultMaxFiles
+	}
+
+	if limits.MaxStderr == 0 {
+		limits.MaxStderr = defaultMaxStderr
+	}
+
+	return limits
+}
+
+// limitedBuffer buffers up to limit bytes, a larger write fails and calls onExceed once.
+type limitedBuffer struct {
+	limit    int64
+	onExceed func()
+
+	mu       sync.Mutex
+	buf      bytes.Buffer
+	exceeded bool
+}
+
+// Write implements io.Writer.
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.exceeded || int64(b.buf.Len()+len(p)) > b.limit {
+		if !b.exceeded {
+			b.exceeded = true
+			b.onExceed()
+		}
+
+		return 0, core.ErrOutputLimitExceeded
+	}
+
+	return b.buf.Write(p)
+}
+
+// cappedBuffer keeps the first limit bytes and discards the rest.
+type cappedBuffer struct {
+	limit int64
+
+	mu        sync.Mutex
+	buf       bytes.Buffer
+	truncated bool
+}
+
+// Write implements io.Writer, it never fails.
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	n := len(p)
+	if left := b.limit - int64(b.buf.Len()); int64(len(p)) > left {
+		p = p[:max(left, 0)]
+		b.truncated = true
+	}
+
+	b.buf.Write(p)
+	return n, nil
+}
+
+// String returns the kept bytes, marked if the rest was discarded.
+func (b *cappedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.truncated {
+		return b.buf.String() + "…(truncated)"
+	}
+
+	return b.buf.String()
+}
@@ -0,0 +1,210 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sipki-tech/dev-platform/database"
+	"github.com/sipki-tech/dev-platform/logger"
+)
+
+const (
+	defaultEscalationFactor = 2.0
+	defaultCgroupRoot       = "/sys/fs/cgroup"
+	// peakSampleInterval is how often the memory usage peak of a running container is read.
+	peakSampleInterval = 20 * time.Millisecond
+)
+
+var (
+	// containerCgroups are cgroup directories of a docker container relative to the cgroup root:
+	// cgroup v2 with the systemd and the cgroupfs drivers, then cgroup v1.
+	containerCgroups = []string{
+		"system.slice/docker-%s.scope",
+		"docker/%s",
+		"memory/system.slice/docker-%s.scope",
+		"memory/docker/%s",
+	}
+	// peakFiles hold the memory usage peak of a cgroup: cgroup v2, then cgroup v1.
+	peakFiles = []string{"memory.peak", "memory.max_usage_in_bytes"}
+)
+
+// OOMConfig is an opt-in policy for retrying OOM-killed plugins with a larger memory limit.
+type OOMConfig struct {
+	// Enabled turns escalation on, it is disabled by default.
+	Enabled bool `json:"enabled,omitempty"`
+	// MaxMemory is the memory limit ceiling, required if enabled.
+	MaxMemory ByteSize `json:"max_memory,omitempty"`
+	// Factor multiplies the memory limit on every retry, defaults to 2.
+	Factor float64 `json:"factor,omitempty"`
+}
+
+// withDefaults returns a copy of the configuration with defaults for omitted fields.
+func (oom OOMConfig) withDefaults() OOMConfig {
+	if oom.Factor == 0 {
+		oom.Factor = defaultEscalationFactor
+	}
+
+	return oom
+}
+
+// escalate returns the memory limit for the next run after an OOM kill with the given limit.
+// It returns false if escalation is disabled or the ceiling is already reached.
+func (oom OOMConfig) escalate(memory ByteSize) (ByteSize, bool) {
+	oom = oom.withDefaults()
+	if !oom.Enabled || memory <= 0 || memory >= oom.MaxMemory {
+		return 0, false
+	}
+
+	next := float64(memory) * oom.Factor
+	if next >= float64(oom.MaxMemory) || next > math.MaxInt64 {
+		return oom.MaxMemory, true
+	}
+
+	return ByteSize(next), true
+}
+
+// withMemory returns a copy of the configuration with the memory limit raised,
+// swap is raised so it is never below the memory limit.
+func (dockerConfig DockerConfig) withMemory(memory ByteSize) DockerConfig {
+	if dockerConfig.MemorySwap > 0 && dockerConfig.MemorySwap < memory {
+		dockerConfig.MemorySwap = memory
+	}
+
+	dockerConfig.Memory = memory
+	return dockerConfig
+}
+
+// memoryRecorder records the memory usage peak of plugins which succeeded after OOM escalation,
+// so operators can raise the configured limit.
+type memoryRecorder struct {
+	sql *database.SQL
+	// cgroupRoot is where the cgroup hierarchy of the docker host is mounted.
+	cgroupRoot string
+	peak       *prometheus.GaugeVec
+}
+
+func newMemoryRecorder(reg *prometheus.Registry, namespace, subsystem string, conn *database.SQL, cgroupRoot string) *memoryRecorder {
+	if cgroupRoot == "" {
+		cgroupRoot = defaultCgroupRoot
+	}
+
+	m := &memoryRecorder{
+		sql:        conn,
+		cgroupRoot: cgroupRoot,
+		peak: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      "plugin_peak_memory_bytes",
+				Help:      "Highest memory usage observed for a plugin which succeeded after OOM escalation.",
+			},
+			[]string{"plugin"},
+		),
+	}
+
+	reg.MustRegister(m.peak)
+
+	return m
+}
+
+// record stores the observed memory usage peak of the plugin which succeeded with the memory limit
+// if it is higher than the recorded one. A zero peak means it couldn't be read and isn't stored.
+// Failures are logged, they don't affect the request.
+func (m *memoryRecorder) record(ctx context.Context, p *plugin, memory, observed ByteSize) {
+	name := p.GroupName + "/" + p.Name + ":" + p.Version
+	log := logger.FromContext(ctx)
+
+	log.Warn("plugin needed more memory than configured",
+		slog.String("plugin", name),
+		slog.String("memory", memory.String()),
+		slog.String("peak_memory", observed.String()),
+	)
+
+	if observed <= 0 {
+		log.Warn("failed to read plugin peak memory, check the cgroup root",
+			slog.String("plugin", name),
+			slog.String("cgroup_root", m.cgroupRoot),
+		)
+
+		return
+	}
+
+	var peak int64
+	err := m.sql.NoTx(func(d *sqlx.DB) error {
+		const query = "update plugins set peak_memory = greatest(peak_memory, $1) where id = $2 returning peak_memory"
+
+		return d.GetContext(ctx, &peak, query, int64(observed), p.ID)
+	})
+	if err != nil {
+		log.Error("failed to record plugin peak memory",
+			slog.String("plugin", name),
+			slog.String(logger.Error.String(), err.Error()),
+		)
+
+		return
+	}
+
+	m.peak.WithLabelValues(name).Set(float64(peak))
+}
+
+// watchPeak reads the memory usage peak of the container, whose ID docker writes to cidFile,
+// until stop is closed and returns the highest value read, 0 if the cgroup couldn't be read.
+// The cgroup is removed when the container exits, so the usage right before the exit may be missed.
+func (m *memoryRecorder) watchPeak(cidFile string, stop <-chan struct{}) ByteSize {
+	ticker := time.NewTicker(peakSampleInterval)
+	defer ticker.Stop()
+
+	var (
+		peakFile string
+		peak     ByteSize
+	)
+	for {
+		if peakFile == "" {
+			peakFile = m.findPeakFile(cidFile)
+		}
+
+		if peakFile != "" {
+			data, err := os.ReadFile(peakFile)
+			if err == nil {
+				value, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+				if err == nil {
+					peak = max(peak, ByteSize(value))
+				}
+			}
+		}
+
+		select {
+		case <-stop:
+			return peak
+		case <-ticker.C:
+		}
+	}
+}
+
+// findPeakFile returns the file with the memory usage peak of the container, empty if it isn't known yet.
+func (m *memoryRecorder) findPeakFile(cidFile string) string {
+	id, err := os.ReadFile(cidFile)
+	if err != nil || len(id) == 0 {
+		return ""
+	}
+
+	for _, cgroup := range containerCgroups {
+		for _, file := range peakFiles {
+			path := filepath.Join(m.cgroupRoot, fmt.Sprintf(cgroup, strings.TrimSpace(string(id))), file)
+			if _, err := os.Stat(path); err == nil {
+				return path
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,71 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+// probe is the latest canary probe of a plugin.
+type probe struct {
+	PluginID  uuid.UUID `db:"plugin_id"`
+	Healthy   bool      `db:"healthy"`
+	LatencyMS int64     `db:"latency_ms"`
+	Error     string    `db:"error"`
+	CheckedAt time.Time `db:"checked_at"`
+}
+
+// SaveProbe implements core.Registry.
+func (r *Registry) SaveProbe(ctx context.Context, pluginID uuid.UUID, status core.ProbeStatus) error {
+	err := r.sql.NoTx(func(d *sqlx.DB) error {
+		const query = `insert into plugin_probes (plugin_id, healthy, latency_ms, error, checked_at)
+	values (:plugin_id, :healthy, :latency_ms, :error, :checked_at)
+	on conflict (plugin_id) do update set healthy = excluded.healthy, latency_ms = excluded.latency_ms,
+	error = excluded.error, checked_at = excluded.checked_at`
+
+		_, err := d.NamedExecContext(ctx, query, probe{
+			PluginID:  pluginID,
+			Healthy:   status.Healthy,
+			LatencyMS: status.Latency.Milliseconds(),
+			Error:     status.Error,
+			CheckedAt: status.CheckedAt,
+		})
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("r.sql.NoTx: %w", err)
+	}
+
+	return nil
+}
+
+// Probes implements core.Registry.
+func (r *Registry) Probes(ctx context.Context) (map[uuid.UUID]core.ProbeStatus, error) {
+	var probes []probe
+	err := r.sql.NoTx(func(d *sqlx.DB) error {
+		const query = "select plugin_id, healthy, latency_ms, error, checked_at from plugin_probes"
+
+		return d.SelectContext(ctx, &probes, query)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("r.sql.NoTx: %w", err)
+	}
+
+	result := make(map[uuid.UUID]core.ProbeStatus, len(probes))
+	for _, p := range probes {
+		result[p.PluginID] = core.ProbeStatus{
+			Healthy:   p.Healthy,
+			Latency:   time.Duration(p.LatencyMS) * time.Millisecond,
+			Error:     p.Error,
+			CheckedAt: p.CheckedAt,
+		}
+	}
+
+	return result, nil
+}
@@ -8,8 +8,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/url"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"time"
 
 	"github.com/gofrs/uuid/v5"
@@ -18,7 +21,9 @@ import (
 	"github.com/sipki-tech/dev-platform/database"
 	"github.com/sipki-tech/dev-platform/database/connectors"
 	"github.com/sipki-tech/dev-platform/database/migrations"
+	"github.com/sipki-tech/dev-platform/logger"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
 	"google.golang.org/protobuf/types/pluginpb"
 
 	"github.com/easyp-tech/service/internal/core"
@@ -31,18 +36,46 @@ type (
 	// DockerConfig represents Docker execution configuration
 	DockerConfig struct {
 		Network    string            `json:"network,omitempty"`
-		Memory     string            `json:"memory,omitempty"`
-		CPUs       string            `json:"cpus,omitempty"`
+		Memory     ByteSize          `json:"memory,omitempty"`
+		CPUs       CPUQuantity       `json:"cpus,omitempty"`
 		User       string            `json:"user,omitempty"`
 		Env        map[string]string `json:"env,omitempty"`
 		WorkingDir string            `json:"working_dir,omitempty"`
 		ReadOnly   bool              `json:"read_only,omitempty"`
 		TmpFS      map[string]string `json:"tmpfs,omitempty"`
+
+		// MemorySwap is the memory plus swap limit, defaults to Memory (no swap).
+		MemorySwap ByteSize `json:"memory_swap,omitempty"`
+		// PidsLimit limits the number of processes, defaults to 64, at most 4096.
+		PidsLimit int64 `json:"pids_limit,omitempty"`
+		// CapDrop lists capabilities to drop, defaults to ALL.
+		CapDrop []string `json:"cap_drop,omitempty"`
+		// NoNewPrivileges defaults to true.
+		NoNewPrivileges *bool `json:"no_new_privileges,omitempty"`
+		// SeccompProfile is a seccomp profile path, defaults to the docker profile.
+		SeccompProfile string `json:"seccomp_profile,omitempty"`
+		// AppArmorProfile is an AppArmor profile name, defaults to the docker profile.
+		AppArmorProfile string `json:"apparmor_profile,omitempty"`
+		// Ulimits maps a limit name to "soft:hard" (e.g., "nofile": "1024:1024").
+		Ulimits map[string]string `json:"ulimits,omitempty"`
+		// Runtime is an OCI runtime (e.g., "runsc"), defaults to SandboxPolicy.DefaultRuntime.
+		Runtime string `json:"runtime,omitempty"`
+		// DeviceReadBPS maps a device path to a read rate limit (e.g., "/dev/sda": "10mb").
+		DeviceReadBPS map[string]ByteSize `json:"device_read_bps,omitempty"`
+		// DeviceWriteBPS maps a device path to a write rate limit (e.g., "/dev/sda": "10mb").
+		DeviceWriteBPS map[string]ByteSize `json:"device_write_bps,omitempty"`
+		// Privileged is rejected unless SandboxPolicy.AllowPrivileged is set.
+		Privileged bool `json:"privileged,omitempty"`
 	}
 
 	// PluginConfig represents the complete plugin configuration
 	PluginConfig struct {
-		Docker *DockerConfig `json:"docker,omitempty"`
+		Docker      *DockerConfig      `json:"docker,omitempty"`
+		Limits      *LimitsConfig      `json:"limits,omitempty"`
+		Retry       *RetryConfig       `json:"retry,omitempty"`
+		OOM         *OOMConfig         `json:"oom_escalation,omitempty"`
+		Determinism *DeterminismConfig `json:"determinism,omitempty"`
+		Shadow      *ShadowConfig      `json:"shadow,omitempty"`
 		// Future extensions can be added here:
 		// Security SecurityConfig `json:"security,omitempty"`
 		// Monitoring MonitoringConfig `json:"monitoring,omitempty"`
@@ -55,12 +88,27 @@ type (
 		MigrateDir string
 		Driver     string
 		Domain     string
+		// DigestCheckInterval is how often tags of pinned plugins are compared with their digests.
+		DigestCheckInterval time.Duration
+		Sandbox             SandboxPolicy
+		// MaxTimeout caps plugin execution timeouts, defaults to 5m.
+		MaxTimeout time.Duration
+		// Defaults is the server-wide plugin configuration layer (JSON), overridden by group, plugin and version ones.
+		Defaults json.RawMessage
+		// CgroupRoot is where the cgroup hierarchy of the docker host is mounted, defaults to /sys/fs/cgroup.
+		// It is read to record the memory usage peak of plugins after OOM escalation.
+		CgroupRoot string
 	}
 
 	// Registry is a registry for EasyP plugin server.
 	Registry struct {
-		sql    *database.SQL
-		domain *url.URL
+		sql        *database.SQL
+		domain     *url.URL
+		digests    *digestChecker
+		sandbox    SandboxPolicy
+		defaults   json.RawMessage
+		maxTimeout time.Duration
+		memory     *memoryRecorder
 	}
 
 	// plugin is a plugin in the registry.
@@ -69,11 +117,27 @@ type (
 		GroupName string          `db:"group_name"`
 		Name      string          `db:"name"`
 		Version   string          `db:"version"`
-		Config    json.RawMessage `db:"config"`
+		Config    json.RawMessage `db:"config"` // Version configuration layer.
+		Digest    string          `db:"digest"` // Empty if the plugin is not pinned.
+		Signature []byte          `db:"signature"`
 		CreatedAt time.Time       `db:"created_at"`
-
-		domain       *url.URL     `db:"-"`
-		pluginConfig PluginConfig `db:"-"`
+		Status    string          `db:"status"`
+		// Capabilities are NULL until they are probed.
+		SupportedFeatures     sql.NullInt64 `db:"supported_features"`
+		MinimumEdition        sql.NullInt32 `db:"minimum_edition"`
+		MaximumEdition        sql.NullInt32 `db:"maximum_edition"`
+		CapabilitiesCheckedAt sql.NullTime  `db:"capabilities_checked_at"`
+		// GroupConfig and NameConfig are configuration layers shared by the group and by all versions of the plugin.
+		GroupConfig json.RawMessage `db:"group_config"`
+		NameConfig  json.RawMessage `db:"name_config"`
+
+		mergedConfig json.RawMessage `db:"-"`
+		domain       *url.URL        `db:"-"`
+		digests      *digestChecker  `db:"-"`
+		sandbox      SandboxPolicy   `db:"-"`
+		maxTimeout   time.Duration   `db:"-"`
+		memory       *memoryRecorder `db:"-"`
+		pluginConfig PluginConfig    `db:"-"`
 	}
 )
 
@@ -85,6 +149,15 @@ func New(ctx context.Context, reg *prometheus.Registry, namespace string, cfg Co
 	returnErrs := []error{ // List of core.Err… returned by Repo methods.
 		core.ErrNotFound,
 		core.ErrInvalidPluginName,
+		core.ErrDigestMismatch,
+		core.ErrAmbiguousPlugin,
+		core.ErrUnsafeSandbox,
+		core.ErrInvalidPluginConfig,
+	}
+
+	err := ValidatePluginConfig(cfg.Defaults, cfg.Sandbox)
+	if err != nil {
+		return nil, fmt.Errorf("ValidatePluginConfig: default configuration: %w", err)
 	}
 
 	migrates, err := migrations.Parse(cfg.MigrateDir)
@@ -111,40 +184,127 @@ func New(ctx context.Context, reg *prometheus.Registry, namespace string, cfg Co
 	}
 
 	return &Registry{
-		sql:    conn,
-		domain: u,
+		sql:        conn,
+		domain:     u,
+		digests:    newDigestChecker(reg, namespace, subsystem, cfg.DigestCheckInterval),
+		sandbox:    cfg.Sandbox,
+		defaults:   cfg.Defaults,
+		maxTimeout: cfg.MaxTimeout,
+		memory:     newMemoryRecorder(reg, namespace, subsystem, conn, cfg.CgroupRoot),
 	}, nil
 }
 
+// pluginColumns selects a plugin with its group and plugin configuration layers.
+const pluginColumns = `select p.id, p.group_name, p.name, p.version, p.config, p.digest, p.signature, p.created_at, p.status,
+	p.supported_features, p.minimum_edition, p.maximum_edition, p.capabilities_checked_at,
+	coalesce(g.config, '{}') as group_config, coalesce(c.config, '{}') as name_config
+	from plugins p
+	left join plugin_groups g on g.group_name = p.group_name
+	left join plugin_configs c on c.group_name = p.group_name and c.name = p.name`
+
 // Get implements core.Registry.
-func (r *Registry) Get(ctx context.Context, pluginGroup, pluginName, pluginVersion string) (p core.Plugin, err error) {
+func (r *Registry) Get(ctx context.Context, ref core.PluginRef) (core.Plugin, error) {
+	p, err := r.find(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("r.find: %w", err)
+	}
+
+	// Parse plugin configuration
+	p.pluginConfig, err = ParsePluginConfig(p.mergedConfig)
+	if err != nil {
+		return nil, fmt.Errorf("ParsePluginConfig: %w (plugin: %s)", err, ref)
+	}
+
+	return p, nil
+}
+
+// EffectiveConfig implements core.Registry.
+func (r *Registry) EffectiveConfig(ctx context.Context, ref core.PluginRef) (*core.EffectiveConfig, error) {
+	p, err := r.find(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("r.find: %w", err)
+	}
+
+	cfg, err := ParsePluginConfig(p.mergedConfig)
+	if err != nil {
+		return nil, fmt.Errorf("ParsePluginConfig: %w (plugin: %s)", err, ref)
+	}
+
+	docker := cfg.Docker.withDefaults(r.sandbox)
+	cfg.Docker = &docker
+	limits := cfg.Limits.withDefaults(r.maxTimeout)
+	cfg.Limits = &limits
+	retry := cfg.Retry.withDefaults()
+	cfg.Retry = &retry
+	oom := cfg.OOM.withDefaults()
+	cfg.OOM = &oom
+
+	effective, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	return &core.EffectiveConfig{
+		Plugin: *p.Info(ctx),
+		Config: effective,
+		Layers: r.layers(p),
+	}, nil
+}
+
+// find returns the plugin with merged configuration layers.
+func (r *Registry) find(ctx context.Context, ref core.PluginRef) (p *plugin, err error) {
 	err = r.sql.NoTx(func(d *sqlx.DB) error {
-		dbFormat := plugin{}
+		query := pluginColumns + " where p.group_name = $1 and p.name = $2 and p.version = $3"
+		args := []any{ref.Group, ref.Name, ref.Version}
+
+		if ref.Version == "" {
+			query = pluginColumns + " where p.group_name = $1 and p.name = $2 and p.digest = $3"
+			args = []any{ref.Group, ref.Name, ref.Digest}
+		}
 
-		query := "select id, group_name, name, version, config, created_at from plugins where group_name = $1 and name = $2 and version = $3"
-		args := []any{pluginGroup, pluginName, pluginVersion}
+		var rows []plugin
+		err := d.SelectContext(ctx, &rows, query+" limit 2", args...)
+		if err == nil && len(rows) == 0 && ref.IsChannel() {
+			// A version which isn't semver looks like a channel, it wins over the channel of the same name.
+			const channelQuery = pluginColumns + ` join plugin_channels ch on ch.plugin_id = p.id
+	where ch.group_name = $1 and ch.name = $2 and ch.channel = $3`
 
-		if pluginVersion == "latest" {
-			query = "select id, group_name, name, version, config, created_at from plugins where group_name = $1 and name = $2 order by version desc limit 1"
-			args = []any{pluginGroup, pluginName}
+			err = d.SelectContext(ctx, &rows, channelQuery+" limit 2", args...)
 		}
 
-		err := d.GetContext(ctx, &dbFormat, query, args...)
 		switch {
-		case errors.Is(err, sql.ErrNoRows):
-			return fmt.Errorf("d.GetContext: %w (plugin groupd: %s; plugin name: %s; plugin version: %s)", core.ErrNotFound, pluginGroup, pluginName, pluginVersion)
 		case err != nil:
-			return fmt.Errorf("d.GetContext: %w (plugin groupd: %s; plugin name: %s; plugin version: %s)", err, pluginGroup, pluginName, pluginVersion)
+			return fmt.Errorf("d.SelectContext: %w (plugin: %s)", err, ref)
+		case len(rows) == 0:
+			return fmt.Errorf("d.SelectContext: %w (plugin: %s)", core.ErrNotFound, ref)
+		case len(rows) > 1:
+			// Only a digest can match several versions, e.g. a version re-tagged under another name.
+			return fmt.Errorf("%w: digest is shared by %s and %s, specify the version (plugin: %s)",
+				core.ErrAmbiguousPlugin, rows[0].Version, rows[1].Version, ref)
 		}
+		dbFormat := rows[0]
 
-		// Parse plugin configuration
-		if len(dbFormat.Config) > 0 {
-			if err := json.Unmarshal(dbFormat.Config, &dbFormat.pluginConfig); err != nil {
-				return fmt.Errorf("json.Unmarshal config: %w", err)
-			}
+		switch {
+		case ref.Digest == "":
+		case dbFormat.Digest == "":
+			// The plugin is not pinned, run the requested content, docker verifies it.
+			// The signature, if any, can't cover content which wasn't registered.
+			dbFormat.Digest = ref.Digest
+			dbFormat.Signature = nil
+		case dbFormat.Digest != ref.Digest:
+			return fmt.Errorf("%w: %s is pinned to %s (plugin: %s)", core.ErrDigestMismatch, dbFormat.Version, dbFormat.Digest, ref)
+		}
+
+		dbFormat.mergedConfig, err = r.mergeLayers(&dbFormat)
+		if err != nil {
+			return fmt.Errorf("r.mergeLayers: %w (plugin: %s)", err, ref)
 		}
 
 		dbFormat.domain = r.domain
+		dbFormat.digests = r.digests
+		dbFormat.sandbox = r.sandbox
+		dbFormat.maxTimeout = r.maxTimeout
+		dbFormat.memory = r.memory
 		p = &dbFormat
 		return nil
 	})
@@ -155,11 +315,33 @@ func (r *Registry) Get(ctx context.Context, pluginGroup, pluginName, pluginVersi
 	return p, nil
 }
 
+// layers returns configuration layers of the plugin from the lowest to the highest priority.
+func (r *Registry) layers(p *plugin) []core.ConfigLayer {
+	return []core.ConfigLayer{
+		{Source: core.ConfigSourceServer, Config: r.defaults},
+		{Source: core.ConfigSourceGroup, Config: p.GroupConfig},
+		{Source: core.ConfigSourcePlugin, Config: p.NameConfig},
+		{Source: core.ConfigSourceVersion, Config: p.Config},
+	}
+}
+
+// mergeLayers merges configuration layers of the plugin: server defaults, group, plugin and version.
+func (r *Registry) mergeLayers(p *plugin) (json.RawMessage, error) {
+	layers := r.layers(p)
+	configs := make([]json.RawMessage, 0, len(layers))
+	for _, layer := range layers {
+		configs = append(configs, layer.Config)
+	}
+
+	return MergeConfigs(configs...)
+}
+
 // List implements core.Registry.
 func (r *Registry) List(ctx context.Context, filter core.PluginFilter) ([]core.PluginInfo, error) {
 	var plugins []plugin
 	err := r.sql.NoTx(func(d *sqlx.DB) error {
-		query := "select id, group_name, name, version, created_at from plugins where 1=1"
+		query := `select id, group_name, name, version, digest, created_at, status,
+	supported_features, minimum_edition, maximum_edition, capabilities_checked_at from plugins where 1=1`
 		var args []any
 		argID := 1
 
@@ -178,6 +360,11 @@ func (r *Registry) List(ctx context.Context, filter core.PluginFilter) ([]core.P
 			args = append(args, filter.Version)
 			argID++
 		}
+		if filter.Status != "" {
+			query += fmt.Sprintf(" and status = $%d", argID)
+			args = append(args, string(filter.Status))
+			argID++
+		}
 
 		return d.SelectContext(ctx, &plugins, query, args...)
 	})
@@ -193,6 +380,41 @@ func (r *Registry) List(ctx context.Context, filter core.PluginFilter) ([]core.P
 	return result, nil
 }
 
+// ConfigProblem is an invalid configuration of a registered plugin.
+type ConfigProblem struct {
+	Plugin core.PluginInfo
+	Err    error
+}
+
+// ValidateConfigs checks configurations of all registered plugins and returns the invalid ones.
+func (r *Registry) ValidateConfigs(ctx context.Context) ([]ConfigProblem, error) {
+	var plugins []plugin
+	err := r.sql.NoTx(func(d *sqlx.DB) error {
+		const query = pluginColumns + " order by p.group_name, p.name, p.version"
+
+		return d.SelectContext(ctx, &plugins, query)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("r.sql.NoTx: %w", err)
+	}
+
+	var problems []ConfigProblem
+	for _, p := range plugins {
+		merged, err := r.mergeLayers(&p)
+		if err == nil {
+			err = ValidatePluginConfig(merged, r.sandbox)
+		}
+		if err != nil {
+			problems = append(problems, ConfigProblem{
+				Plugin: *p.Info(ctx),
+				Err:    err,
+			})
+		}
+	}
+
+	return problems, nil
+}
+
 // Close database connection.
 func (r *Registry) Close() error {
 	return r.sql.Close()
@@ -210,92 +432,191 @@ func (p *plugin) Generate(ctx context.Context, req *pluginpb.CodeGeneratorReques
 		return nil, fmt.Errorf("proto.Marshal: %w", err)
 	}
 
-	imageName := p.domain.String() + "/" + p.GroupName + "/" + p.Name + ":" + p.Version
+	repository := p.domain.String() + "/" + p.GroupName + "/" + p.Name
 
-	// Build Docker command with configuration from database
-	args := []string{"run", "--rm", "-i"}
-
-	// Get Docker configuration
-	dockerConfig := p.pluginConfig.Docker
-
-	// Apply Docker configuration from database
-	if dockerConfig.Network != "" {
-		args = append(args, "--network="+dockerConfig.Network)
-	} else {
-		// Default security: no network access
-		args = append(args, "--network=none")
+	imageName := repository + ":" + p.Version
+	if p.Digest != "" {
+		// Run the pinned content, a re-pushed tag must not change what is generated.
+		imageName = repository + "@" + p.Digest
 	}
 
-	if dockerConfig.Memory != "" {
-		args = append(args, "--memory="+dockerConfig.Memory)
-	} else {
-		// Default memory limit
-		args = append(args, "--memory=128m")
+	// Candidates may be given by digest only, without a tag to compare.
+	if p.Digest != "" && p.Version != "" {
+		err = p.digests.verify(ctx, repository, p.Version, p.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("p.digests.verify: %w", err)
+		}
 	}
 
-	if dockerConfig.CPUs != "" {
-		args = append(args, "--cpus="+dockerConfig.CPUs)
-	} else {
-		// Default CPU limit
-		args = append(args, "--cpus=1.0")
-	}
+	docker := p.pluginConfig.Docker.withDefaults(p.sandbox)
+	configured := docker.Memory
 
-	if dockerConfig.User != "" {
-		args = append(args, "--user="+dockerConfig.User)
-	}
+	// The timeout covers the request, runs escalated after an OOM kill share it.
+	limits := p.pluginConfig.Limits.withDefaults(p.maxTimeout)
+	errTimeout := fmt.Errorf("%w: exceeded %s", core.ErrPluginTimeout, limits.Timeout)
+	ctx, cancelTimeout := context.WithTimeoutCause(ctx, time.Duration(limits.Timeout), errTimeout)
+	defer cancelTimeout()
 
-	if dockerConfig.WorkingDir != "" {
-		args = append(args, "--workdir="+dockerConfig.WorkingDir)
-	}
+	for {
+		// The peak is only needed to tune plugins which needed escalation.
+		var peak *ByteSize
+		if docker.Memory > configured {
+			peak = new(ByteSize)
+		}
+
+		resp, err := p.run(ctx, imageName, requestData, &docker, peak)
+
+		var execErr *core.ExecutionError
+		if err != nil && errors.As(err, &execErr) && execErr.Failure == core.FailureOOMKilled {
+			memory, ok := p.pluginConfig.OOM.escalate(docker.Memory)
+			if ok {
+				logger.FromContext(ctx).Warn("plugin was OOM-killed, retrying with more memory",
+					slog.String("plugin", execErr.Plugin),
+					slog.String("memory", docker.Memory.String()),
+					slog.String("next_memory", memory.String()),
+				)
+
+				docker = docker.withMemory(memory)
+				continue
+			}
+		}
+
+		if err != nil {
+			return nil, err
+		}
 
-	if dockerConfig.ReadOnly {
-		args = append(args, "--read-only")
+		if peak != nil {
+			p.memory.record(ctx, p, docker.Memory, *peak)
+		}
+
+		return resp, nil
 	}
+}
 
-	// Add environment variables
-	for key, value := range dockerConfig.Env {
-		args = append(args, "--env", key+"="+value)
+// run executes the plugin container once, ctx carries the plugin timeout.
+// If peak is not nil, it is set to the observed memory usage peak.
+func (p *plugin) run(ctx context.Context, imageName string, requestData []byte, docker *DockerConfig, peak *ByteSize) (*pluginpb.CodeGeneratorResponse, error) {
+	// Build Docker command with configuration from database
+	args, err := dockerArgs(docker, p.sandbox)
+	if err != nil {
+		return nil, fmt.Errorf("dockerArgs: %w", err)
 	}
 
-	// Add tmpfs mounts
-	for path, opts := range dockerConfig.TmpFS {
-		if opts != "" {
-			args = append(args, "--tmpfs", path+":"+opts)
-		} else {
-			args = append(args, "--tmpfs", path)
+	if peak != nil {
+		dir, err := os.MkdirTemp("", "easyp-plugin-")
+		if err != nil {
+			return nil, fmt.Errorf("os.MkdirTemp: %w", err)
 		}
+		defer os.RemoveAll(dir)
+
+		cidFile := filepath.Join(dir, "cid")
+		args = append(args, "--cidfile="+cidFile)
+
+		stop := make(chan struct{})
+		watched := make(chan ByteSize, 1)
+		go func() { watched <- p.memory.watchPeak(cidFile, stop) }()
+		defer func() {
+			close(stop)
+			*peak = <-watched
+		}()
 	}
 
 	args = append(args, imageName)
 
+	limits := p.pluginConfig.Limits.withDefaults(p.maxTimeout)
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	stdout := &limitedBuffer{
+		limit: int64(limits.MaxOutput),
+		onExceed: func() {
+			cancel(fmt.Errorf("%w: output is larger than %s", core.ErrOutputLimitExceeded, limits.MaxOutput))
+		},
+	}
+	stderr := &cappedBuffer{limit: int64(limits.MaxStderr)}
+
 	cmd := exec.CommandContext(ctx, "docker", args...)
 	cmd.Stdin = bytes.NewReader(requestData)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	// Interrupt the docker client, it stops the container, then kill it if it doesn't exit.
+	cmd.Cancel = func() error { return cmd.Process.Signal(os.Interrupt) }
+	cmd.WaitDelay = killDelay
+
+	start := time.Now()
+	err = cmd.Run()
+	execErr := &core.ExecutionError{
+		Plugin:   p.GroupName + "/" + p.Name + ":" + p.Version,
+		Stderr:   stderr.String(),
+		Duration: time.Since(start),
+	}
 
-	output, err := cmd.Output()
-	if err != nil {
-		var exitErr *exec.ExitError
-		if errors.As(err, &exitErr) {
-			return nil, fmt.Errorf("plugin execution failed: %s, stderr: %s", err, string(exitErr.Stderr))
-		}
+	cause := context.Cause(ctx)
+	var exitErr *exec.ExitError
+	switch {
+	case errors.Is(cause, core.ErrOutputLimitExceeded):
+		return nil, fmt.Errorf("cmd.Run: %w", cause)
+	case errors.Is(cause, core.ErrPluginTimeout):
+		execErr.Failure = core.FailureTimeout
+		execErr.Err = cause
+	case ctx.Err() != nil:
+		// Cancelled by the caller.
+		return nil, fmt.Errorf("cmd.Run: %w", ctx.Err())
+	case err == nil:
+	case errors.As(err, &exitErr):
+		execErr.ExitCode = exitErr.ExitCode()
+		execErr.Failure = classifyExit(execErr.ExitCode, execErr.Stderr)
+		execErr.Transient = isTransient(execErr.Failure, execErr.ExitCode, execErr.Stderr)
+		execErr.Message = fmt.Sprintf("exited with code %d (memory limit %s)", execErr.ExitCode, docker.Memory)
+	case errors.Is(err, exec.ErrNotFound):
+		execErr.Failure = core.FailureDaemonUnavailable
+		execErr.Err = err
+	default:
+		return nil, fmt.Errorf("cmd.Run: %w", err)
+	}
 
-		return nil, fmt.Errorf("cmd.Output: %w", err)
+	if execErr.Failure != "" {
+		return nil, execErr
 	}
 
 	var response pluginpb.CodeGeneratorResponse
-	if err := proto.Unmarshal(output, &response); err != nil {
+	if err := proto.Unmarshal(stdout.buf.Bytes(), &response); err != nil {
 		return nil, fmt.Errorf("proto.Unmarshal: %w", err)
 	}
 
+	if len(response.File) > limits.MaxFiles {
+		return nil, fmt.Errorf("%w: %d files generated, at most %d allowed", core.ErrOutputLimitExceeded, len(response.File), limits.MaxFiles)
+	}
+
 	return &response, nil
 }
 
 // Info implements core.Plugin.
 func (p *plugin) Info(_ context.Context) *core.PluginInfo {
 	return &core.PluginInfo{
-		ID:        p.ID,
-		Group:     p.GroupName,
-		Name:      p.Name,
-		Version:   p.Version,
-		CreatedAt: p.CreatedAt,
+		ID:           p.ID,
+		Group:        p.GroupName,
+		Name:         p.Name,
+		Version:      p.Version,
+		CreatedAt:    p.CreatedAt,
+		Digest:       p.Digest,
+		Signature:    p.Signature,
+		Status:       core.PluginStatus(p.Status),
+		Capabilities: p.capabilities(),
+	}
+}
+
+// capabilities returns the probed capabilities, nil if the plugin wasn't probed yet.
+func (p *plugin) capabilities() *core.Capabilities {
+	if !p.CapabilitiesCheckedAt.Valid {
+		return nil
+	}
+
+	return &core.Capabilities{
+		SupportedFeatures: uint64(p.SupportedFeatures.Int64),
+		MinimumEdition:    descriptorpb.Edition(p.MinimumEdition.Int32),
+		MaximumEdition:    descriptorpb.Edition(p.MaximumEdition.Int32),
+		CheckedAt:         p.CapabilitiesCheckedAt.Time,
 	}
 }
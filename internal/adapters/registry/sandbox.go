@@ -0,0 +1,225 @@
+package registry
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+const (
+	defaultMemory    = 128 * mib
+	defaultCPUs      = 1.0
+	defaultPidsLimit = 64
+	maxPidsLimit     = 4096
+	defaultNoFile    = "1024:1024"
+
+	unconfined = "unconfined"
+)
+
+// SandboxPolicy is a server-wide policy for plugin containers.
+// Unsafe settings in plugin configuration are rejected unless explicitly allowed.
+type SandboxPolicy struct {
+	// AllowPrivileged allows plugins to run privileged containers.
+	AllowPrivileged bool
+	// AllowNetwork allows plugins to use the bridge network, plugins run without network by default.
+	AllowNetwork bool
+	// AllowHostNetwork allows plugins to use host (or another container's) network namespace.
+	AllowHostNetwork bool
+	// AllowUnconfined allows plugins to disable seccomp, AppArmor and no-new-privileges
+	// and to keep docker's default capabilities with an empty cap_drop.
+	AllowUnconfined bool
+	// AllowUnlimitedSwap allows plugins to set memory_swap to -1.
+	AllowUnlimitedSwap bool
+	// DefaultRuntime is the OCI runtime for plugins which don't set one (e.g., "runsc").
+	// Empty means the docker daemon default.
+	DefaultRuntime string
+}
+
+// withDefaults returns a copy of the configuration with secure defaults for omitted fields.
+func (dockerConfig DockerConfig) withDefaults(policy SandboxPolicy) DockerConfig {
+	if dockerConfig.Network == "" {
+		// Default security: no network access
+		dockerConfig.Network = "none"
+	}
+
+	if dockerConfig.Memory == 0 {
+		// Default memory limit
+		dockerConfig.Memory = defaultMemory
+	}
+
+	if dockerConfig.MemorySwap == 0 {
+		// Default: no swap, memory and swap limits are equal
+		dockerConfig.MemorySwap = dockerConfig.Memory
+	}
+
+	if dockerConfig.CPUs == 0 {
+		// Default CPU limit
+		dockerConfig.CPUs = defaultCPUs
+	}
+
+	if dockerConfig.PidsLimit == 0 {
+		// Default: protoc plugins don't fork much, stop fork bombs
+		dockerConfig.PidsLimit = defaultPidsLimit
+	}
+
+	if dockerConfig.CapDrop == nil {
+		// Default: plugins need no capabilities
+		dockerConfig.CapDrop = []string{"ALL"}
+	}
+
+	if dockerConfig.NoNewPrivileges == nil {
+		noNewPrivileges := true
+		dockerConfig.NoNewPrivileges = &noNewPrivileges
+	}
+
+	if dockerConfig.Runtime == "" {
+		dockerConfig.Runtime = policy.DefaultRuntime
+	}
+
+	if _, ok := dockerConfig.Ulimits["nofile"]; !ok {
+		ulimits := maps.Clone(dockerConfig.Ulimits)
+		if ulimits == nil {
+			ulimits = make(map[string]string, 1)
+		}
+		ulimits["nofile"] = defaultNoFile
+		dockerConfig.Ulimits = ulimits
+	}
+
+	return dockerConfig
+}
+
+// dockerArgs builds "docker run" arguments for the plugin configuration.
+// Omitted fields get secure defaults.
+func dockerArgs(dockerConfig *DockerConfig, policy SandboxPolicy) ([]string, error) {
+	err := policy.check(dockerConfig)
+	if err != nil {
+		return nil, fmt.Errorf("policy.check: %w", err)
+	}
+
+	cfg := dockerConfig.withDefaults(policy)
+
+	args := []string{
+		"run", "--rm", "-i",
+		"--network=" + cfg.Network,
+		"--memory=" + cfg.Memory.String(),
+		"--memory-swap=" + cfg.MemorySwap.String(),
+		"--cpus=" + cfg.CPUs.String(),
+		"--pids-limit=" + strconv.FormatInt(cfg.PidsLimit, 10),
+	}
+
+	for _, capability := range cfg.CapDrop {
+		args = append(args, "--cap-drop="+capability)
+	}
+
+	if *cfg.NoNewPrivileges {
+		args = append(args, "--security-opt=no-new-privileges")
+	}
+
+	if cfg.SeccompProfile != "" {
+		args = append(args, "--security-opt=seccomp="+cfg.SeccompProfile)
+	}
+
+	if cfg.AppArmorProfile != "" {
+		args = append(args, "--security-opt=apparmor="+cfg.AppArmorProfile)
+	}
+
+	if cfg.Runtime != "" {
+		args = append(args, "--runtime="+cfg.Runtime)
+	}
+
+	if cfg.Privileged {
+		args = append(args, "--privileged")
+	}
+
+	if cfg.User != "" {
+		args = append(args, "--user="+cfg.User)
+	}
+
+	if cfg.WorkingDir != "" {
+		args = append(args, "--workdir="+cfg.WorkingDir)
+	}
+
+	if cfg.ReadOnly {
+		args = append(args, "--read-only")
+	}
+
+	// Add ulimits
+	for _, name := range slices.Sorted(maps.Keys(cfg.Ulimits)) {
+		args = append(args, "--ulimit", name+"="+cfg.Ulimits[name])
+	}
+
+	// Add disk I/O limits
+	for _, device := range slices.Sorted(maps.Keys(cfg.DeviceReadBPS)) {
+		args = append(args, "--device-read-bps", device+":"+cfg.DeviceReadBPS[device].String())
+	}
+	for _, device := range slices.Sorted(maps.Keys(cfg.DeviceWriteBPS)) {
+		args = append(args, "--device-write-bps", device+":"+cfg.DeviceWriteBPS[device].String())
+	}
+
+	// Add environment variables
+	for key, value := range cfg.Env {
+		args = append(args, "--env", key+"="+value)
+	}
+
+	// Add tmpfs mounts
+	for path, opts := range cfg.TmpFS {
+		if opts != "" {
+			args = append(args, "--tmpfs", path+":"+opts)
+		} else {
+			args = append(args, "--tmpfs", path)
+		}
+	}
+
+	return args, nil
+}
+
+// check rejects unsafe settings the policy doesn't allow.
+func (policy SandboxPolicy) check(dockerConfig *DockerConfig) error {
+	if dockerConfig.Privileged && !policy.AllowPrivileged {
+		return fmt.Errorf("%w: privileged containers are not allowed", core.ErrUnsafeSandbox)
+	}
+
+	if (dockerConfig.Network == "host" || strings.HasPrefix(dockerConfig.Network, "container:")) && !policy.AllowHostNetwork {
+		return fmt.Errorf("%w: network %q is not allowed", core.ErrUnsafeSandbox, dockerConfig.Network)
+	}
+
+	if dockerConfig.Network == "bridge" && !policy.AllowNetwork {
+		return fmt.Errorf("%w: network %q is not allowed", core.ErrUnsafeSandbox, dockerConfig.Network)
+	}
+
+	if dockerConfig.MemorySwap == -1 && !policy.AllowUnlimitedSwap {
+		return fmt.Errorf("%w: unlimited swap is not allowed", core.ErrUnsafeSandbox)
+	}
+
+	if !policy.AllowUnconfined {
+		switch {
+		case dockerConfig.CapDrop != nil && len(dockerConfig.CapDrop) == 0:
+			return fmt.Errorf("%w: empty cap_drop is not allowed", core.ErrUnsafeSandbox)
+		case dockerConfig.SeccompProfile == unconfined:
+			return fmt.Errorf("%w: unconfined seccomp profile is not allowed", core.ErrUnsafeSandbox)
+		case dockerConfig.AppArmorProfile == unconfined:
+			return fmt.Errorf("%w: unconfined AppArmor profile is not allowed", core.ErrUnsafeSandbox)
+		case dockerConfig.NoNewPrivileges != nil && !*dockerConfig.NoNewPrivileges:
+			return fmt.Errorf("%w: disabling no-new-privileges is not allowed", core.ErrUnsafeSandbox)
+		}
+	}
+
+	return nil
+}
+
+// allowedNetwork reports whether the plugin may request the network mode, user-defined networks aren't allowed.
+// Empty means the default.
+func allowedNetwork(network string) bool {
+	switch network {
+	case "", "none", "bridge", "host":
+		return true
+	}
+
+	name, ok := strings.CutPrefix(network, "container:")
+
+	return ok && name != ""
+}
@@ -0,0 +1,71 @@
+package registry
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+func TestValidatePluginConfigPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		config  string
+		policy  SandboxPolicy
+		wantErr bool
+	}{
+		{name: "defaults", config: `{}`},
+		{name: "privileged", config: `{"docker": {"privileged": true}}`, wantErr: true},
+		{name: "privileged allowed", config: `{"docker": {"privileged": true}}`, policy: SandboxPolicy{AllowPrivileged: true}},
+		{name: "bridge", config: `{"docker": {"network": "bridge"}}`, wantErr: true},
+		{name: "bridge allowed", config: `{"docker": {"network": "bridge"}}`, policy: SandboxPolicy{AllowNetwork: true}},
+		{name: "host network", config: `{"docker": {"network": "host"}}`, policy: SandboxPolicy{AllowNetwork: true}, wantErr: true},
+		{name: "container network", config: `{"docker": {"network": "container:db"}}`, wantErr: true},
+		{name: "unconfined seccomp", config: `{"docker": {"seccomp_profile": "unconfined"}}`, wantErr: true},
+		{name: "new privileges", config: `{"docker": {"no_new_privileges": false}}`, wantErr: true},
+		{name: "empty cap_drop", config: `{"docker": {"cap_drop": []}}`, wantErr: true},
+		{name: "empty cap_drop unconfined", config: `{"docker": {"cap_drop": []}}`, policy: SandboxPolicy{AllowUnconfined: true}},
+		{name: "cap_drop", config: `{"docker": {"cap_drop": ["NET_RAW"]}}`},
+		{name: "unlimited swap", config: `{"docker": {"memory_swap": -1}}`, wantErr: true},
+		{name: "unlimited swap string", config: `{"docker": {"memory_swap": "-1"}}`, wantErr: true},
+		{name: "unlimited swap allowed", config: `{"docker": {"memory_swap": -1}}`, policy: SandboxPolicy{AllowUnlimitedSwap: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidatePluginConfig([]byte(tt.config), tt.policy)
+			if tt.wantErr {
+				if !errors.Is(err, core.ErrUnsafeSandbox) {
+					t.Fatalf("ValidatePluginConfig(%s) = %v, want ErrUnsafeSandbox", tt.config, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ValidatePluginConfig(%s): %v", tt.config, err)
+			}
+		})
+	}
+}
+
+func TestDockerArgsDefaults(t *testing.T) {
+	t.Parallel()
+
+	args, err := dockerArgs(&DockerConfig{}, SandboxPolicy{})
+	if err != nil {
+		t.Fatalf("dockerArgs: %v", err)
+	}
+
+	for _, want := range []string{"--network=none", "--memory=128m", "--memory-swap=128m", "--pids-limit=64", "--cap-drop=ALL",
+		"--security-opt=no-new-privileges"} {
+		if !slices.Contains(args, want) {
+			t.Errorf("dockerArgs() = %q, missing %q", args, want)
+		}
+	}
+}
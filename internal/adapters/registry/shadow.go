@@ -0,0 +1,98 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+type (
+	// ShadowConfig runs a fraction of requests against a candidate version of the plugin as well,
+	// to compare the outputs before the candidate is promoted.
+	ShadowConfig struct {
+		// Version is the exact candidate version, empty disables shadowing.
+		Version string `json:"version,omitempty"`
+		// Fraction of requests which are shadowed, from 0 to 1.
+		Fraction float64 `json:"fraction,omitempty"`
+	}
+
+	// shadowStats is a shadow execution added to the statistics of a plugin pair.
+	shadowStats struct {
+		PluginID       uuid.UUID `db:"plugin_id"`
+		CandidateID    uuid.UUID `db:"candidate_id"`
+		Identical      int64     `db:"identical"`
+		Divergent      int64     `db:"divergent"`
+		Failed         int64     `db:"failed"`
+		Files          int64     `db:"files"`
+		DifferingFiles int64     `db:"differing_files"`
+		LastDiffering  string    `db:"last_differing"` // JSON array, a string keeps lib/pq from sending bytea.
+		LastError      string    `db:"last_error"`
+		LastRunAt      time.Time `db:"last_run_at"`
+	}
+)
+
+// ShadowPolicy implements core.Plugin.
+func (p *plugin) ShadowPolicy(_ context.Context) core.ShadowPolicy {
+	return core.ShadowPolicy{
+		Version:  p.pluginConfig.Shadow.Version,
+		Fraction: p.pluginConfig.Shadow.Fraction,
+	}
+}
+
+// SaveShadowResult implements core.Registry.
+func (r *Registry) SaveShadowResult(ctx context.Context, result core.ShadowResult) error {
+	differing, err := json.Marshal(result.Differing)
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	row := shadowStats{
+		PluginID:       result.PluginID,
+		CandidateID:    result.CandidateID,
+		Files:          int64(result.Files),
+		DifferingFiles: int64(len(result.Differing)),
+		LastDiffering:  string(differing),
+		LastError:      result.Error,
+		LastRunAt:      result.RunAt,
+	}
+
+	switch result.Outcome {
+	case core.ShadowIdentical:
+		row.Identical = 1
+	case core.ShadowDivergent:
+		row.Divergent = 1
+	case core.ShadowFailed:
+		row.Failed = 1
+	}
+
+	err = r.sql.NoTx(func(d *sqlx.DB) error {
+		const query = `insert into plugin_shadow_stats (plugin_id, candidate_id, runs, identical, divergent, failed,
+	files, differing_files, last_differing, last_error, last_run_at)
+	values (:plugin_id, :candidate_id, 1, :identical, :divergent, :failed,
+	:files, :differing_files, :last_differing, :last_error, :last_run_at)
+	on conflict (plugin_id, candidate_id) do update set runs = plugin_shadow_stats.runs + 1,
+	identical = plugin_shadow_stats.identical + excluded.identical,
+	divergent = plugin_shadow_stats.divergent + excluded.divergent,
+	failed = plugin_shadow_stats.failed + excluded.failed,
+	files = plugin_shadow_stats.files + excluded.files,
+	differing_files = plugin_shadow_stats.differing_files + excluded.differing_files,
+	last_differing = case when excluded.divergent > 0 then excluded.last_differing else plugin_shadow_stats.last_differing end,
+	last_error = case when excluded.failed > 0 then excluded.last_error else plugin_shadow_stats.last_error end,
+	last_run_at = excluded.last_run_at`
+
+		_, err := d.NamedExecContext(ctx, query, row)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("r.sql.NoTx: %w", err)
+	}
+
+	return nil
+}
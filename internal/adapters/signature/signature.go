@@ -0,0 +1,105 @@
+// Package signature verifies detached signatures of plugin images.
+package signature
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+var _ core.Verifier = &Verifier{}
+
+var (
+	errUnsupportedKey = errors.New("unsupported public key")
+	errNoPublicKey    = errors.New("no public key")
+)
+
+// Verifier checks plugin signatures against locally configured public keys.
+// A plugin is trusted if its signature is valid for any of the keys.
+type Verifier struct {
+	keys []crypto.PublicKey
+}
+
+// New loads PEM encoded (PKIX) ed25519 or ECDSA public keys from the files and returns a Verifier.
+// Without key files verification is disabled and every plugin is trusted, a key file without keys is an error.
+func New(keyFiles []string) (*Verifier, error) {
+	v := &Verifier{
+		keys: make([]crypto.PublicKey, 0, len(keyFiles)),
+	}
+
+	for _, path := range keyFiles {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("os.ReadFile: %w", err)
+		}
+
+		loaded := len(v.keys)
+		for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+			key, err := x509.ParsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("x509.ParsePKIXPublicKey %s: %w", path, err)
+			}
+
+			switch key.(type) {
+			case ed25519.PublicKey, *ecdsa.PublicKey:
+			default:
+				return nil, fmt.Errorf("%w: %s: %T", errUnsupportedKey, path, key)
+			}
+
+			v.keys = append(v.keys, key)
+		}
+
+		if len(v.keys) == loaded {
+			return nil, fmt.Errorf("%w: %s has no PEM block", errNoPublicKey, path)
+		}
+	}
+
+	return v, nil
+}
+
+// Enabled reports whether plugins are verified.
+func (v *Verifier) Enabled() bool {
+	return len(v.keys) > 0
+}
+
+// Verify implements core.Verifier.
+func (v *Verifier) Verify(_ context.Context, info core.PluginInfo) error {
+	if !v.Enabled() {
+		return nil
+	}
+
+	if info.Digest == "" {
+		return fmt.Errorf("%w: %s/%s:%s is not pinned to a digest", core.ErrUntrustedPlugin, info.Group, info.Name, info.Version)
+	}
+
+	if len(info.Signature) == 0 {
+		return fmt.Errorf("%w: %s/%s:%s is not signed", core.ErrUntrustedPlugin, info.Group, info.Name, info.Version)
+	}
+
+	payload := info.SignedPayload()
+	hash := sha256.Sum256(payload)
+
+	for _, key := range v.keys {
+		switch key := key.(type) {
+		case ed25519.PublicKey:
+			if ed25519.Verify(key, payload, info.Signature) {
+				return nil
+			}
+		case *ecdsa.PublicKey:
+			if ecdsa.VerifyASN1(key, hash[:], info.Signature) {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("%w: invalid signature of %s", core.ErrUntrustedPlugin, payload)
+}
@@ -0,0 +1,179 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sipki-tech/dev-platform/grpc_helper"
+	"github.com/sipki-tech/dev-platform/logger"
+	"github.com/sipki-tech/dev-platform/metrics"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	admin "github.com/easyp-tech/service/api/admin/v1"
+	"github.com/easyp-tech/service/internal/core"
+)
+
+var _ admin.AdminAPIServer = (*Admin)(nil)
+
+// errUnauthenticated is returned for admin calls without the admin token.
+var errUnauthenticated = errors.New("unauthenticated")
+
+// Admin provides the administration API server implementation.
+type Admin struct {
+	app *core.Core
+}
+
+// NewAdmin creates and returns the administration gRPC server, it must be served on a separate port
+// which isn't exposed to plugin users. Calls must carry the "authorization: Bearer <token>" metadata.
+func NewAdmin(
+	ctx context.Context,
+	m metrics.Metrics,
+	applications *core.Core,
+	reg *prometheus.Registry,
+	namespace string,
+	token string,
+) *grpc.Server {
+	log := logger.FromContext(ctx)
+	subsystem := "admin"
+
+	grpcMetrics := grpc_helper.NewServerMetrics(reg, namespace, subsystem)
+
+	v := newValidator()
+	auth := tokenAuth(token)
+
+	srv, health := grpc_helper.NewServer(m, log, grpcMetrics, apiError,
+		[]grpc.UnaryServerInterceptor{auth.UnaryServerInterceptor(), v.UnaryServerInterceptor()},
+		[]grpc.StreamServerInterceptor{auth.StreamServerInterceptor(), v.StreamServerInterceptor()},
+	)
+	health.SetServingStatus(admin.AdminAPI_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+
+	api := &Admin{
+		app: applications,
+	}
+	admin.RegisterAdminAPIServer(srv, api)
+
+	return srv
+}
+
+// tokenAuth rejects calls which don't carry the token, health checks are allowed without it.
+type tokenAuth string
+
+func (t tokenAuth) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		err := t.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func (t tokenAuth) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := t.authenticate(stream.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, stream)
+	}
+}
+
+func (t tokenAuth) authenticate(ctx context.Context, method string) error {
+	if strings.HasPrefix(method, "/"+healthpb.Health_ServiceDesc.ServiceName+"/") {
+		return nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		scheme, token, ok := strings.Cut(value, " ")
+		if ok && strings.EqualFold(scheme, "Bearer") && t != "" &&
+			subtle.ConstantTimeCompare([]byte(token), []byte(t)) == 1 {
+			return nil
+		}
+	}
+
+	return errUnauthenticated
+}
+
+// MoveChannel implements admin.AdminAPIServer.
+func (api *Admin) MoveChannel(ctx context.Context, request *admin.MoveChannelRequest) (*admin.MoveChannelResponse, error) {
+	move, err := api.app.MoveChannel(ctx, request.PluginName, request.Channel, request.Reason)
+	if err != nil {
+		return nil, fmt.Errorf("api.app.MoveChannel: %w", err)
+	}
+
+	return &admin.MoveChannelResponse{
+		Move: toChannelMove(*move),
+	}, nil
+}
+
+// RollbackChannel implements admin.AdminAPIServer.
+func (api *Admin) RollbackChannel(ctx context.Context, request *admin.RollbackChannelRequest) (*admin.RollbackChannelResponse, error) {
+	move, err := api.app.RollbackChannel(ctx, request.Plugin, request.Channel, request.Reason)
+	if err != nil {
+		return nil, fmt.Errorf("api.app.RollbackChannel: %w", err)
+	}
+
+	return &admin.RollbackChannelResponse{
+		Move: toChannelMove(*move),
+	}, nil
+}
+
+// Channels implements admin.AdminAPIServer.
+func (api *Admin) Channels(ctx context.Context, request *admin.ChannelsRequest) (*admin.ChannelsResponse, error) {
+	channels, err := api.app.Channels(ctx, request.Plugin)
+	if err != nil {
+		return nil, fmt.Errorf("api.app.Channels: %w", err)
+	}
+
+	response := &admin.ChannelsResponse{
+		Channels: make([]*admin.Channel, 0, len(channels)),
+	}
+
+	for _, channel := range channels {
+		response.Channels = append(response.Channels, &admin.Channel{
+			Name:      channel.Channel,
+			Version:   channel.Version,
+			UpdatedAt: timestamppb.New(channel.UpdatedAt),
+		})
+	}
+
+	return response, nil
+}
+
+// ChannelHistory implements admin.AdminAPIServer.
+func (api *Admin) ChannelHistory(ctx context.Context, request *admin.ChannelHistoryRequest) (*admin.ChannelHistoryResponse, error) {
+	history, err := api.app.ChannelHistory(ctx, request.Plugin, request.Channel)
+	if err != nil {
+		return nil, fmt.Errorf("api.app.ChannelHistory: %w", err)
+	}
+
+	response := &admin.ChannelHistoryResponse{
+		Moves: make([]*admin.ChannelMove, 0, len(history)),
+	}
+
+	for _, move := range history {
+		response.Moves = append(response.Moves, toChannelMove(move))
+	}
+
+	return response, nil
+}
+
+func toChannelMove(move core.ChannelMove) *admin.ChannelMove {
+	return &admin.ChannelMove{
+		Channel:         move.Channel,
+		Version:         move.Version,
+		PreviousVersion: move.Previous,
+		Reason:          move.Reason,
+		MovedAt:         timestamppb.New(move.MovedAt),
+	}
+}
@@ -3,17 +3,23 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sipki-tech/dev-platform/grpc_helper"
 	"github.com/sipki-tech/dev-platform/logger"
 	"github.com/sipki-tech/dev-platform/metrics"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	generator "github.com/easyp-tech/service/api/generator/v1"
@@ -34,9 +40,11 @@ func New(ctx context.Context, m metrics.Metrics, applications *core.Core, reg *p
 
 	grpcMetrics := grpc_helper.NewServerMetrics(reg, namespace, subsystem)
 
+	v := newValidator()
+
 	srv, health := grpc_helper.NewServer(m, log, grpcMetrics, apiError,
-		[]grpc.UnaryServerInterceptor{},
-		[]grpc.StreamServerInterceptor{},
+		[]grpc.UnaryServerInterceptor{v.UnaryServerInterceptor()},
+		[]grpc.StreamServerInterceptor{v.StreamServerInterceptor()},
 	)
 	health.SetServingStatus(generator.ServiceAPI_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
 
@@ -51,8 +59,9 @@ func New(ctx context.Context, m metrics.Metrics, applications *core.Core, reg *p
 // GenerateCode implements generator.PluginGeneratorServiceServer.
 func (api *API) GenerateCode(ctx context.Context, request *generator.GenerateCodeRequest) (*generator.GenerateCodeResponse, error) {
 	resp, err := api.app.Generate(ctx, core.GenerateCodeRequest{
-		PluginName: request.PluginName,
-		Payload:    request.CodeGeneratorRequest,
+		PluginName:        request.PluginName,
+		Payload:           request.CodeGeneratorRequest,
+		VerifyDeterminism: request.VerifyDeterminism,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("api.app.Generate: %w", err)
@@ -60,9 +69,44 @@ func (api *API) GenerateCode(ctx context.Context, request *generator.GenerateCod
 
 	return &generator.GenerateCodeResponse{
 		CodeGeneratorResponse: resp.Payload,
+		Plugin:                toPluginInfo(resp.Plugin),
+		Duration:              durationpb.New(resp.Duration),
+		Cached:                resp.Cached,
+		Determinism:           toDeterminismCheck(resp.Determinism),
 	}, nil
 }
 
+// DiffCode implements generator.ServiceAPIServer.
+func (api *API) DiffCode(ctx context.Context, request *generator.DiffCodeRequest) (*generator.DiffCodeResponse, error) {
+	resp, err := api.app.Diff(ctx, core.DiffCodeRequest{
+		Payload: request.CodeGeneratorRequest,
+		Base:    request.BasePluginName,
+		Head:    request.HeadPluginName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("api.app.Diff: %w", err)
+	}
+
+	response := &generator.DiffCodeResponse{
+		BasePlugin:   toPluginInfo(resp.Base),
+		HeadPlugin:   toPluginInfo(resp.Head),
+		AddedFiles:   resp.Added,
+		RemovedFiles: resp.Removed,
+		ChangedFiles: resp.Changed,
+		Files:        make([]*generator.FileDiff, 0, len(resp.Files)),
+	}
+
+	for _, file := range resp.Files {
+		response.Files = append(response.Files, &generator.FileDiff{
+			Name:        file.Name,
+			Change:      toFileChange(file.Change),
+			UnifiedDiff: file.UnifiedDiff,
+		})
+	}
+
+	return response, nil
+}
+
 // Plugins implements generator.ServiceAPIServer.
 func (api *API) Plugins(ctx context.Context, _ *generator.PluginsRequest) (*generator.PluginsResponse, error) {
 	plugins, err := api.app.ListPlugins(ctx, core.PluginFilter{})
@@ -75,30 +119,233 @@ func (api *API) Plugins(ctx context.Context, _ *generator.PluginsRequest) (*gene
 	}
 
 	for _, p := range plugins {
-		response.Plugins = append(response.Plugins, &generator.PluginInfo{
-			Id:        p.ID.String(),
-			Group:     p.Group,
-			Name:      p.Name,
-			Version:   p.Version,
-			CreatedAt: timestamppb.New(p.CreatedAt),
+		response.Plugins = append(response.Plugins, toPluginInfo(p))
+	}
+
+	return response, nil
+}
+
+// PluginConfig implements generator.ServiceAPIServer.
+func (api *API) PluginConfig(ctx context.Context, request *generator.PluginConfigRequest) (*generator.PluginConfigResponse, error) {
+	cfg, err := api.app.PluginConfig(ctx, request.PluginName)
+	if err != nil {
+		return nil, fmt.Errorf("api.app.PluginConfig: %w", err)
+	}
+
+	effective, err := toStruct(cfg.Config)
+	if err != nil {
+		return nil, fmt.Errorf("toStruct: %w", err)
+	}
+
+	response := &generator.PluginConfigResponse{
+		Plugin:          toPluginInfo(cfg.Plugin),
+		EffectiveConfig: effective,
+		Layers:          make([]*generator.ConfigLayer, 0, len(cfg.Layers)),
+	}
+
+	for _, layer := range cfg.Layers {
+		config, err := toStruct(layer.Config)
+		if err != nil {
+			return nil, fmt.Errorf("toStruct: %s: %w", layer.Source, err)
+		}
+
+		response.Layers = append(response.Layers, &generator.ConfigLayer{
+			Source: toConfigSource(layer.Source),
+			Config: config,
 		})
 	}
 
 	return response, nil
 }
 
+func toStruct(data json.RawMessage) (*structpb.Struct, error) {
+	result := &structpb.Struct{}
+	if len(data) == 0 {
+		return result, nil
+	}
+
+	err := result.UnmarshalJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("result.UnmarshalJSON: %w", err)
+	}
+
+	return result, nil
+}
+
+func toConfigSource(source core.ConfigSource) generator.ConfigSource {
+	switch source {
+	case core.ConfigSourceServer:
+		return generator.ConfigSource_CONFIG_SOURCE_SERVER
+	case core.ConfigSourceGroup:
+		return generator.ConfigSource_CONFIG_SOURCE_GROUP
+	case core.ConfigSourcePlugin:
+		return generator.ConfigSource_CONFIG_SOURCE_PLUGIN
+	case core.ConfigSourceVersion:
+		return generator.ConfigSource_CONFIG_SOURCE_VERSION
+	default:
+		return generator.ConfigSource_CONFIG_SOURCE_NONE
+	}
+}
+
+func toFileChange(change core.FileChange) generator.FileChange {
+	switch change {
+	case core.FileAdded:
+		return generator.FileChange_FILE_CHANGE_ADDED
+	case core.FileRemoved:
+		return generator.FileChange_FILE_CHANGE_REMOVED
+	case core.FileChanged:
+		return generator.FileChange_FILE_CHANGE_CHANGED
+	default:
+		return generator.FileChange_FILE_CHANGE_NONE
+	}
+}
+
+func toPluginInfo(p core.PluginInfo) *generator.PluginInfo {
+	return &generator.PluginInfo{
+		Id:           p.ID.String(),
+		Group:        p.Group,
+		Name:         p.Name,
+		Version:      p.Version,
+		CreatedAt:    timestamppb.New(p.CreatedAt),
+		Digest:       p.Digest,
+		CircuitState: toCircuitState(p.Circuit),
+		Probe:        toProbeStatus(p.Probe),
+		Status:       toPluginStatus(p.Status),
+		Capabilities: toPluginCapabilities(p.Capabilities),
+		Determinism:  toDeterminismStatus(p.Determinism),
+		Channels:     p.Channels,
+	}
+}
+
+func toDeterminismCheck(check *core.DeterminismCheck) *generator.DeterminismCheck {
+	if check == nil {
+		return nil
+	}
+
+	return &generator.DeterminismCheck{
+		Deterministic: check.Deterministic,
+		Files:         check.Files,
+	}
+}
+
+func toDeterminismStatus(status *core.DeterminismStatus) *generator.DeterminismStatus {
+	if status == nil {
+		return nil
+	}
+
+	result := &generator.DeterminismStatus{
+		Checks:           status.Checks,
+		Nondeterministic: status.Nondeterministic,
+		Files:            status.Files,
+		CheckedAt:        timestamppb.New(status.CheckedAt),
+	}
+	if !status.NondeterministicAt.IsZero() {
+		result.NondeterministicAt = timestamppb.New(status.NondeterministicAt)
+	}
+
+	return result
+}
+
+func toPluginCapabilities(capabilities *core.Capabilities) *generator.PluginCapabilities {
+	if capabilities == nil {
+		return nil
+	}
+
+	return &generator.PluginCapabilities{
+		Proto3Optional: capabilities.Proto3Optional(),
+		Editions:       capabilities.Editions(),
+		MinimumEdition: toEdition(capabilities.MinimumEdition),
+		MaximumEdition: toEdition(capabilities.MaximumEdition),
+		CheckedAt:      timestamppb.New(capabilities.CheckedAt),
+	}
+}
+
+func toEdition(edition descriptorpb.Edition) string {
+	if edition == descriptorpb.Edition_EDITION_UNKNOWN {
+		return ""
+	}
+
+	return edition.String()
+}
+
+func toPluginStatus(status core.PluginStatus) generator.PluginStatus {
+	switch status {
+	case core.PluginAvailable:
+		return generator.PluginStatus_PLUGIN_STATUS_AVAILABLE
+	case core.PluginPending:
+		return generator.PluginStatus_PLUGIN_STATUS_PENDING
+	case core.PluginFailed:
+		return generator.PluginStatus_PLUGIN_STATUS_FAILED
+	default:
+		return generator.PluginStatus_PLUGIN_STATUS_NONE
+	}
+}
+
+func toProbeStatus(status *core.ProbeStatus) *generator.ProbeStatus {
+	if status == nil {
+		return nil
+	}
+
+	return &generator.ProbeStatus{
+		Healthy:   status.Healthy,
+		Latency:   durationpb.New(status.Latency),
+		Error:     status.Error,
+		CheckedAt: timestamppb.New(status.CheckedAt),
+	}
+}
+
+func toCircuitState(state core.CircuitState) generator.CircuitState {
+	switch state {
+	case core.CircuitClosed:
+		return generator.CircuitState_CIRCUIT_STATE_CLOSED
+	case core.CircuitHalfOpen:
+		return generator.CircuitState_CIRCUIT_STATE_HALF_OPEN
+	case core.CircuitOpen:
+		return generator.CircuitState_CIRCUIT_STATE_OPEN
+	default:
+		return generator.CircuitState_CIRCUIT_STATE_NONE
+	}
+}
+
 func apiError(err error) *status.Status {
 	if err == nil {
 		return nil
 	}
 
+	var requestErr *requestValidationError
+	if errors.As(err, &requestErr) {
+		return badRequest(requestErr.Error(), requestErr.violations)
+	}
+
+	var validationErr *core.ValidationError
+	if errors.As(err, &validationErr) {
+		return badRequest(validationErr.Error(), fieldViolations(codeGeneratorRequestField, validationErr.Violations))
+	}
+
+	var execErr *core.ExecutionError
+	if errors.As(err, &execErr) {
+		return executionError(err.Error(), execErr)
+	}
+
 	code := codes.Internal
 	switch {
+	case errors.Is(err, errUnauthenticated):
+		code = codes.Unauthenticated
 	case errors.Is(err, core.ErrNotFound):
 		code = codes.NotFound
-	case errors.Is(err, core.ErrInvalidPluginName):
+	case errors.Is(err, core.ErrDigestMismatch), errors.Is(err, core.ErrUntrustedPlugin), errors.Is(err, core.ErrUnsafeSandbox),
+		errors.Is(err, core.ErrInvalidPluginConfig), errors.Is(err, core.ErrPluginUnavailable):
+		code = codes.FailedPrecondition
+	case errors.Is(err, core.ErrInvalidPluginName), errors.Is(err, core.ErrInvalidRequest), errors.Is(err, core.ErrInvalidChannel),
+		errors.Is(err, core.ErrAmbiguousPlugin):
 		code = codes.InvalidArgument
-	case errors.Is(err, core.ErrGenerationFailed):
+	case errors.Is(err, core.ErrCircuitOpen):
+		code = codes.Unavailable
+	case errors.Is(err, core.ErrPluginTimeout):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, core.ErrOutputLimitExceeded):
+		code = codes.ResourceExhausted
+	case errors.Is(err, core.ErrGenerationFailed), errors.Is(err, core.ErrUnsafeOutput):
 		code = codes.Internal
 	case errors.Is(err, context.DeadlineExceeded):
 		code = codes.DeadlineExceeded
@@ -108,3 +355,76 @@ func apiError(err error) *status.Status {
 
 	return status.New(code, err.Error())
 }
+
+// codeGeneratorRequestField is the name of the field carrying the CodeGeneratorRequest
+// in GenerateCodeRequest, core reports violations relative to it.
+const codeGeneratorRequestField = "code_generator_request"
+
+func fieldViolations(prefix string, violations []core.FieldViolation) []*errdetails.BadRequest_FieldViolation {
+	result := make([]*errdetails.BadRequest_FieldViolation, 0, len(violations))
+	for _, v := range violations {
+		field := prefix
+		if v.Field != "" {
+			field += "." + v.Field
+		}
+
+		result = append(result, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: v.Description,
+		})
+	}
+
+	return result
+}
+
+// errorDomain is the google.rpc.ErrorInfo domain of errors returned by the service.
+const errorDomain = "easyp.tech"
+
+func executionError(msg string, execErr *core.ExecutionError) *status.Status {
+	code := codes.Internal
+	switch execErr.Failure {
+	case core.FailurePluginError:
+		code = codes.InvalidArgument
+	case core.FailureExitCode:
+		code = codes.Internal
+	case core.FailureOOMKilled:
+		code = codes.ResourceExhausted
+	case core.FailureTimeout:
+		code = codes.DeadlineExceeded
+	case core.FailureImagePull:
+		code = codes.FailedPrecondition
+	case core.FailureDaemonUnavailable:
+		code = codes.Unavailable
+	}
+
+	st := status.New(code, msg)
+	withDetails, err := st.WithDetails(
+		&errdetails.ErrorInfo{
+			Reason: string(execErr.Failure),
+			Domain: errorDomain,
+			Metadata: map[string]string{
+				"plugin":    execErr.Plugin,
+				"exit_code": strconv.Itoa(execErr.ExitCode),
+				"duration":  execErr.Duration.String(),
+			},
+		},
+		&errdetails.DebugInfo{
+			Detail: execErr.Stderr,
+		},
+	)
+	if err != nil {
+		return st
+	}
+
+	return withDetails
+}
+
+func badRequest(msg string, violations []*errdetails.BadRequest_FieldViolation) *status.Status {
+	st := status.New(codes.InvalidArgument, msg)
+	withDetails, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return st
+	}
+
+	return withDetails
+}
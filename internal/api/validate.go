@@ -0,0 +1,423 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	docv1 "github.com/easyp-tech/protoc-gen-easydoc/doc/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type (
+	// validator enforces constraints declared with doc.v1.field annotations on request messages.
+	// Rules are built lazily from message descriptors and cached by message full name.
+	validator struct {
+		mu    sync.RWMutex
+		rules map[protoreflect.FullName]*messageRules
+	}
+
+	// messageRules holds the fields of a message which must be checked.
+	messageRules struct {
+		fields []fieldRules
+	}
+
+	// fieldRules holds the constraints of a single field.
+	fieldRules struct {
+		desc    protoreflect.FieldDescriptor
+		opts    *docv1.FieldOptions // nil if the field has no constraints of its own
+		pattern *regexp.Regexp
+		nested  bool // the field's message type (or map value type) has rules
+	}
+
+	// requestValidationError is returned when a request violates its declared constraints.
+	requestValidationError struct {
+		violations []*errdetails.BadRequest_FieldViolation
+	}
+
+	// validatedServerStream validates every message received by a stream.
+	validatedServerStream struct {
+		grpc.ServerStream
+		v *validator
+	}
+)
+
+func newValidator() *validator {
+	return &validator{
+		rules: make(map[protoreflect.FullName]*messageRules),
+	}
+}
+
+// Error implements error.
+func (e *requestValidationError) Error() string {
+	msgs := make([]string, 0, len(e.violations))
+	for _, v := range e.violations {
+		msgs = append(msgs, v.GetField()+": "+v.GetDescription())
+	}
+
+	return "invalid request: " + strings.Join(msgs, "; ")
+}
+
+// UnaryServerInterceptor returns an interceptor validating every unary request.
+func (v *validator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		msg, ok := req.(proto.Message)
+		if ok {
+			err := v.validate(msg)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns an interceptor validating every message received by a stream.
+func (v *validator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatedServerStream{ServerStream: stream, v: v})
+	}
+}
+
+// RecvMsg implements grpc.ServerStream.
+func (s *validatedServerStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err != nil {
+		return err
+	}
+
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	return s.v.validate(msg)
+}
+
+func (v *validator) validate(msg proto.Message) error {
+	m := msg.ProtoReflect()
+
+	rules, err := v.messageRules(m.Descriptor())
+	if err != nil {
+		return fmt.Errorf("v.messageRules: %w", err)
+	}
+
+	violations := v.validateMessage(m, rules, "", nil)
+	if len(violations) > 0 {
+		return &requestValidationError{violations: violations}
+	}
+
+	return nil
+}
+
+func (v *validator) validateMessage(
+	m protoreflect.Message,
+	rules *messageRules,
+	prefix string,
+	violations []*errdetails.BadRequest_FieldViolation,
+) []*errdetails.BadRequest_FieldViolation {
+	if rules == nil {
+		return violations
+	}
+
+	for _, f := range rules.fields {
+		path := prefix + string(f.desc.Name())
+
+		if f.opts != nil {
+			violations = append(violations, f.check(m, path)...)
+		}
+
+		if !f.nested || !m.Has(f.desc) {
+			continue
+		}
+
+		var nestedDesc protoreflect.MessageDescriptor
+		if f.desc.IsMap() {
+			nestedDesc = f.desc.MapValue().Message()
+		} else {
+			nestedDesc = f.desc.Message()
+		}
+
+		v.mu.RLock()
+		nested := v.rules[nestedDesc.FullName()]
+		v.mu.RUnlock()
+
+		value := m.Get(f.desc)
+		switch {
+		case f.desc.IsMap():
+			value.Map().Range(func(key protoreflect.MapKey, val protoreflect.Value) bool {
+				violations = v.validateMessage(val.Message(), nested, fmt.Sprintf("%s[%v].", path, key.Interface()), violations)
+				return true
+			})
+		case f.desc.IsList():
+			list := value.List()
+			for i := range list.Len() {
+				violations = v.validateMessage(list.Get(i).Message(), nested, fmt.Sprintf("%s[%d].", path, i), violations)
+			}
+		default:
+			violations = v.validateMessage(value.Message(), nested, path+".", violations)
+		}
+	}
+
+	return violations
+}
+
+// check validates the field's own constraints.
+func (f fieldRules) check(m protoreflect.Message, path string) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+	violation := func(field, format string, args ...any) {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: fmt.Sprintf(format, args...),
+		})
+	}
+
+	if !m.Has(f.desc) {
+		if f.opts.GetRequired() {
+			violation(path, "field is required")
+		}
+
+		return violations
+	}
+
+	value := m.Get(f.desc)
+
+	if f.desc.IsList() || f.desc.IsMap() {
+		length := 0
+		if f.desc.IsList() {
+			length = value.List().Len()
+		} else {
+			length = value.Map().Len()
+		}
+
+		if f.opts.MinLength != nil && length < int(f.opts.GetMinLength()) {
+			violation(path, "must contain at least %d items, got %d", f.opts.GetMinLength(), length)
+		}
+		if f.opts.MaxLength != nil && length > int(f.opts.GetMaxLength()) {
+			violation(path, "must contain at most %d items, got %d", f.opts.GetMaxLength(), length)
+		}
+
+		if f.desc.IsList() {
+			list := value.List()
+			for i := range list.Len() {
+				for _, msg := range f.checkValue(list.Get(i)) {
+					violation(fmt.Sprintf("%s[%d]", path, i), "%s", msg)
+				}
+			}
+		}
+
+		return violations
+	}
+
+	for _, msg := range f.checkValue(value) {
+		violation(path, "%s", msg)
+	}
+
+	return violations
+}
+
+// checkValue validates a single (non-repeated) value and returns descriptions of its violations.
+func (f fieldRules) checkValue(value protoreflect.Value) []string {
+	var msgs []string
+
+	switch f.desc.Kind() {
+	case protoreflect.StringKind:
+		s := value.String()
+		length := utf8.RuneCountInString(s)
+
+		if f.opts.MinLength != nil && length < int(f.opts.GetMinLength()) {
+			msgs = append(msgs, fmt.Sprintf("must be at least %d characters long, got %d", f.opts.GetMinLength(), length))
+		}
+		if f.opts.MaxLength != nil && length > int(f.opts.GetMaxLength()) {
+			msgs = append(msgs, fmt.Sprintf("must be at most %d characters long, got %d", f.opts.GetMaxLength(), length))
+		}
+		if f.pattern != nil && !f.pattern.MatchString(s) {
+			msgs = append(msgs, fmt.Sprintf("value %q does not match pattern %q", s, f.pattern.String()))
+		}
+	case protoreflect.BytesKind:
+		length := len(value.Bytes())
+
+		if f.opts.MinLength != nil && length < int(f.opts.GetMinLength()) {
+			msgs = append(msgs, fmt.Sprintf("must be at least %d bytes long, got %d", f.opts.GetMinLength(), length))
+		}
+		if f.opts.MaxLength != nil && length > int(f.opts.GetMaxLength()) {
+			msgs = append(msgs, fmt.Sprintf("must be at most %d bytes long, got %d", f.opts.GetMaxLength(), length))
+		}
+	default:
+		n, ok := numericValue(f.desc.Kind(), value)
+		if !ok {
+			break
+		}
+
+		if f.opts.Min != nil && n < f.opts.GetMin() {
+			msgs = append(msgs, fmt.Sprintf("must be greater than or equal to %v, got %v", f.opts.GetMin(), n))
+		}
+		if f.opts.Max != nil && n > f.opts.GetMax() {
+			msgs = append(msgs, fmt.Sprintf("must be less than or equal to %v, got %v", f.opts.GetMax(), n))
+		}
+	}
+
+	return msgs
+}
+
+func numericValue(kind protoreflect.Kind, value protoreflect.Value) (float64, bool) {
+	switch kind {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return float64(value.Int()), true
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return float64(value.Uint()), true
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return value.Float(), true
+	case protoreflect.EnumKind:
+		return float64(value.Enum()), true
+	default:
+		return 0, false
+	}
+}
+
+// messageRules returns cached rules for the message, building rules for every message
+// reachable from it on the first call.
+func (v *validator) messageRules(desc protoreflect.MessageDescriptor) (*messageRules, error) {
+	v.mu.RLock()
+	rules, ok := v.rules[desc.FullName()]
+	v.mu.RUnlock()
+	if ok {
+		return rules, nil
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	rules, ok = v.rules[desc.FullName()]
+	if ok {
+		return rules, nil
+	}
+
+	// Collect every message reachable from desc.
+	reachable := make(map[protoreflect.FullName]protoreflect.MessageDescriptor)
+	queue := []protoreflect.MessageDescriptor{desc}
+	for len(queue) > 0 {
+		md := queue[0]
+		queue = queue[1:]
+
+		if _, ok := reachable[md.FullName()]; ok {
+			continue
+		}
+		reachable[md.FullName()] = md
+
+		fields := md.Fields()
+		for i := range fields.Len() {
+			if nested := fieldMessage(fields.Get(i)); nested != nil {
+				queue = append(queue, nested)
+			}
+		}
+	}
+
+	// A message has rules if one of its fields has constraints or refers to a message with rules.
+	// Iterate until a fixed point to handle recursive messages.
+	hasRules := make(map[protoreflect.FullName]bool, len(reachable))
+	for name, md := range reachable {
+		if known, ok := v.rules[name]; ok {
+			hasRules[name] = known != nil
+			continue
+		}
+
+		fields := md.Fields()
+		for i := range fields.Len() {
+			if fieldOptions(fields.Get(i)) != nil {
+				hasRules[name] = true
+				break
+			}
+		}
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for name, md := range reachable {
+			if hasRules[name] {
+				continue
+			}
+
+			fields := md.Fields()
+			for i := range fields.Len() {
+				if nested := fieldMessage(fields.Get(i)); nested != nil && hasRules[nested.FullName()] {
+					hasRules[name] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	for name, md := range reachable {
+		if _, ok := v.rules[name]; ok {
+			continue
+		}
+
+		if !hasRules[name] {
+			v.rules[name] = nil
+			continue
+		}
+
+		built := &messageRules{}
+		fields := md.Fields()
+		for i := range fields.Len() {
+			fd := fields.Get(i)
+
+			f := fieldRules{desc: fd, opts: fieldOptions(fd)}
+			if nested := fieldMessage(fd); nested != nil {
+				f.nested = hasRules[nested.FullName()]
+			}
+
+			if f.opts == nil && !f.nested {
+				continue
+			}
+
+			if pattern := f.opts.GetPattern(); pattern != "" {
+				re, err := regexp.Compile(pattern)
+				if err != nil {
+					return nil, fmt.Errorf("regexp.Compile %s: %w", fd.FullName(), err)
+				}
+				f.pattern = re
+			}
+
+			built.fields = append(built.fields, f)
+		}
+
+		v.rules[name] = built
+	}
+
+	return v.rules[desc.FullName()], nil
+}
+
+// fieldOptions returns doc.v1.field constraints of the field which apply to requests.
+func fieldOptions(fd protoreflect.FieldDescriptor) *docv1.FieldOptions {
+	if fd.Options() == nil || !proto.HasExtension(fd.Options(), docv1.E_Field) {
+		return nil
+	}
+
+	opts, ok := proto.GetExtension(fd.Options(), docv1.E_Field).(*docv1.FieldOptions)
+	if !ok || opts.GetOutputOnly() {
+		return nil
+	}
+
+	return opts
+}
+
+// fieldMessage returns the message type of the field, or of its map values.
+func fieldMessage(fd protoreflect.FieldDescriptor) protoreflect.MessageDescriptor {
+	if fd.IsMap() {
+		return fd.MapValue().Message()
+	}
+
+	return fd.Message()
+}
@@ -0,0 +1,181 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// CircuitState is the state of a plugin circuit breaker.
+type CircuitState int
+
+// Circuit breaker states.
+const (
+	// CircuitClosed lets executions through.
+	CircuitClosed CircuitState = iota
+	// CircuitHalfOpen lets a single trial execution through after the open timeout.
+	CircuitHalfOpen
+	// CircuitOpen rejects executions without running the plugin.
+	CircuitOpen
+)
+
+// String implements fmt.Stringer.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitHalfOpen:
+		return "half-open"
+	case CircuitOpen:
+		return "open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int(s))
+	}
+}
+
+// BreakerConfig configures per plugin version circuit breakers.
+type BreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures which opens the circuit, 0 disables breakers.
+	FailureThreshold int
+	// OpenTimeout is how long the circuit stays open before a trial execution is allowed.
+	OpenTimeout time.Duration
+}
+
+type (
+	// breakers tracks circuit breakers of plugin versions.
+	breakers struct {
+		cfg BreakerConfig
+
+		mu       sync.Mutex
+		circuits map[string]*circuit // Key: group/name:version.
+	}
+
+	circuit struct {
+		state    CircuitState
+		failures int
+		openedAt time.Time
+		trial    bool // A trial execution is running in the half-open state.
+	}
+)
+
+func newBreakers(cfg BreakerConfig) *breakers {
+	return &breakers{
+		cfg:      cfg,
+		circuits: make(map[string]*circuit),
+	}
+}
+
+// allow returns an error wrapping ErrCircuitOpen if the plugin must not be executed.
+// It returns the circuit state after the check.
+func (b *breakers) allow(plugin string) (CircuitState, error) {
+	if b.cfg.FailureThreshold <= 0 {
+		return CircuitClosed, nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[plugin]
+	if !ok {
+		return CircuitClosed, nil
+	}
+
+	switch c.state {
+	case CircuitOpen:
+		retryAfter := b.cfg.OpenTimeout - time.Since(c.openedAt)
+		if retryAfter > 0 {
+			return c.state, fmt.Errorf("%w: %s failed %d consecutive times, retry after %s",
+				ErrCircuitOpen, plugin, c.failures, retryAfter.Round(time.Millisecond))
+		}
+
+		c.state = CircuitHalfOpen
+		c.trial = true
+	case CircuitHalfOpen:
+		if c.trial {
+			return c.state, fmt.Errorf("%w: %s is being checked after %d consecutive failures",
+				ErrCircuitOpen, plugin, c.failures)
+		}
+
+		c.trial = true
+	case CircuitClosed:
+	}
+
+	return c.state, nil
+}
+
+// record updates the circuit with the execution result and returns its new state.
+// Every execution failure counts, except the ones caused by the caller, which leave the circuit as is.
+func (b *breakers) record(plugin string, err error) CircuitState {
+	if b.cfg.FailureThreshold <= 0 {
+		return CircuitClosed
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[plugin]
+	if !ok {
+		c = &circuit{state: CircuitClosed}
+		b.circuits[plugin] = c
+	}
+	c.trial = false
+
+	switch {
+	case err == nil:
+		c.state = CircuitClosed
+		c.failures = 0
+
+		return c.state
+	case callerError(err):
+		return c.state
+	}
+
+	c.failures++
+	if c.state == CircuitHalfOpen || c.failures >= b.cfg.FailureThreshold {
+		c.state = CircuitOpen
+		c.openedAt = time.Now()
+	}
+
+	return c.state
+}
+
+// state returns the circuit state of the plugin.
+func (b *breakers) state(plugin string) CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[plugin]
+	if !ok {
+		return CircuitClosed
+	}
+
+	return c.state
+}
+
+// open returns plugins with open circuits, sorted.
+func (b *breakers) open() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var plugins []string
+	for plugin, c := range b.circuits {
+		if c.state == CircuitOpen {
+			plugins = append(plugins, plugin)
+		}
+	}
+
+	slices.Sort(plugins)
+	return plugins
+}
+
+// callerError reports whether the execution failed because of the request or its cancellation,
+// such failures say nothing about the plugin. Plugin timeouts wrap ErrPluginTimeout, not the context errors.
+func callerError(err error) bool {
+	var validationErr *ValidationError
+
+	return errors.As(err, &validationErr) || errors.Is(err, ErrInvalidRequest) ||
+		errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
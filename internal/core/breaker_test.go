@@ -0,0 +1,215 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+const testPlugin = "grpc/go:v1.0.0"
+
+var errTestFailure = &ExecutionError{Failure: FailureExitCode, Plugin: testPlugin, Message: "exit status 1"}
+
+func TestBreakers(t *testing.T) {
+	t.Parallel()
+
+	b := newBreakers(BreakerConfig{FailureThreshold: 2, OpenTimeout: time.Hour})
+
+	mustAllow := func(want CircuitState) {
+		t.Helper()
+
+		state, err := b.allow(testPlugin)
+		if err != nil {
+			t.Fatalf("allow: %v", err)
+		}
+		if state != want {
+			t.Fatalf("allow = %s, want %s", state, want)
+		}
+	}
+	mustReject := func() {
+		t.Helper()
+
+		_, err := b.allow(testPlugin)
+		if !errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("allow = %v, want ErrCircuitOpen", err)
+		}
+	}
+	mustRecord := func(err error, want CircuitState) {
+		t.Helper()
+
+		if state := b.record(testPlugin, err); state != want {
+			t.Fatalf("record(%v) = %s, want %s", err, state, want)
+		}
+		if state := b.state(testPlugin); state != want {
+			t.Fatalf("state = %s, want %s", state, want)
+		}
+	}
+	expireOpenTimeout := func() {
+		b.mu.Lock()
+		b.circuits[testPlugin].openedAt = time.Now().Add(-2 * time.Hour)
+		b.mu.Unlock()
+	}
+
+	mustAllow(CircuitClosed)
+	mustRecord(errTestFailure, CircuitClosed)
+
+	// A success resets consecutive failures.
+	mustRecord(nil, CircuitClosed)
+	mustRecord(errTestFailure, CircuitClosed)
+
+	// Caller errors say nothing about the plugin.
+	for _, err := range []error{
+		&ValidationError{Violations: []FieldViolation{{Field: "file_to_generate"}}},
+		fmt.Errorf("check: %w", ErrInvalidRequest),
+		context.Canceled,
+		fmt.Errorf("run: %w", context.DeadlineExceeded),
+	} {
+		mustRecord(err, CircuitClosed)
+	}
+
+	mustRecord(fmt.Errorf("run: %w", ErrPluginTimeout), CircuitOpen)
+	if open := b.open(); len(open) != 1 || open[0] != testPlugin {
+		t.Fatalf("open = %q, want [%s]", open, testPlugin)
+	}
+	mustReject()
+
+	// A failed trial opens the circuit again at once.
+	expireOpenTimeout()
+	mustAllow(CircuitHalfOpen)
+	mustReject()
+	mustRecord(errTestFailure, CircuitOpen)
+	mustReject()
+
+	// A cancelled trial lets the next request try.
+	expireOpenTimeout()
+	mustAllow(CircuitHalfOpen)
+	mustRecord(context.Canceled, CircuitHalfOpen)
+	mustAllow(CircuitHalfOpen)
+
+	// A successful trial closes the circuit.
+	mustRecord(nil, CircuitClosed)
+	mustAllow(CircuitClosed)
+	if open := b.open(); len(open) != 0 {
+		t.Fatalf("open = %q, want none", open)
+	}
+}
+
+func TestBreakersDisabled(t *testing.T) {
+	t.Parallel()
+
+	b := newBreakers(BreakerConfig{})
+	for range 10 {
+		if state := b.record(testPlugin, errTestFailure); state != CircuitClosed {
+			t.Fatalf("record = %s, want closed", state)
+		}
+	}
+
+	if _, err := b.allow(testPlugin); err != nil {
+		t.Fatalf("allow: %v", err)
+	}
+}
+
+type (
+	// stubPlugin returns the same result to every request.
+	stubPlugin struct {
+		info PluginInfo
+		resp *pluginpb.CodeGeneratorResponse
+		err  error
+	}
+
+	stubRegistry struct {
+		Registry
+
+		plugin *stubPlugin
+	}
+
+	stubMetrics struct {
+		Metrics
+
+		states []CircuitState
+	}
+
+	trustingVerifier struct{}
+)
+
+func (p *stubPlugin) Generate(context.Context, *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error) {
+	return p.resp, p.err
+}
+
+func (p *stubPlugin) Info(context.Context) *PluginInfo          { return &p.info }
+func (p *stubPlugin) RetryPolicy(context.Context) RetryPolicy   { return RetryPolicy{MaxAttempts: 1} }
+func (p *stubPlugin) VerifyDeterminism(context.Context) bool    { return false }
+func (p *stubPlugin) ShadowPolicy(context.Context) ShadowPolicy { return ShadowPolicy{} }
+
+func (r stubRegistry) Get(context.Context, PluginRef) (Plugin, error) { return r.plugin, nil }
+
+func (m *stubMetrics) CircuitState(_ context.Context, _ PluginInfo, state CircuitState) error {
+	m.states = append(m.states, state)
+	return nil
+}
+
+func (m *stubMetrics) UnsafeOutput(context.Context, PluginInfo) error { return nil }
+
+func (trustingVerifier) Verify(context.Context, PluginInfo) error { return nil }
+
+func TestGenerateRecordsInvalidResponses(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		resp *pluginpb.CodeGeneratorResponse
+	}{
+		{
+			name: "plugin error",
+			resp: &pluginpb.CodeGeneratorResponse{Error: proto.String("unsupported option")},
+		},
+		{
+			name: "unsafe output",
+			resp: &pluginpb.CodeGeneratorResponse{File: []*pluginpb.CodeGeneratorResponse_File{{Name: proto.String("../escape.go")}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			plugin := &stubPlugin{
+				info: PluginInfo{Group: "grpc", Name: "go", Version: "v1.0.0", Status: PluginAvailable},
+				resp: tt.resp,
+			}
+			metrics := &stubMetrics{}
+			c := New(metrics, stubRegistry{plugin: plugin}, trustingVerifier{}, Limits{}, BreakerConfig{FailureThreshold: 2, OpenTimeout: time.Hour})
+
+			req := GenerateCodeRequest{
+				PluginName: testPlugin,
+				Payload: &pluginpb.CodeGeneratorRequest{
+					FileToGenerate: []string{"a.proto"},
+					ProtoFile:      []*descriptorpb.FileDescriptorProto{{Name: proto.String("a.proto")}},
+				},
+			}
+
+			for range 2 {
+				_, err := c.Generate(context.Background(), req)
+				if err == nil || errors.Is(err, ErrCircuitOpen) {
+					t.Fatalf("Generate = %v, want the response rejected", err)
+				}
+			}
+
+			_, err := c.Generate(context.Background(), req)
+			if !errors.Is(err, ErrCircuitOpen) {
+				t.Fatalf("Generate = %v, want ErrCircuitOpen", err)
+			}
+
+			want := []CircuitState{CircuitClosed, CircuitOpen}
+			if fmt.Sprint(metrics.states) != fmt.Sprint(want) {
+				t.Fatalf("recorded states %v, want %v", metrics.states, want)
+			}
+		})
+	}
+}
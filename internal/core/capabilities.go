@@ -0,0 +1,210 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/sipki-tech/dev-platform/logger"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// Capabilities are the features a plugin declares in CodeGeneratorResponse.
+type Capabilities struct {
+	// SupportedFeatures is a bitmask of CodeGeneratorResponse.Feature values.
+	SupportedFeatures uint64
+	// MinimumEdition and MaximumEdition are the supported editions range,
+	// EDITION_UNKNOWN if the plugin doesn't declare it.
+	MinimumEdition descriptorpb.Edition
+	MaximumEdition descriptorpb.Edition
+	CheckedAt      time.Time
+}
+
+// Proto3Optional reports whether the plugin supports proto3 optional fields.
+func (c Capabilities) Proto3Optional() bool {
+	return c.SupportedFeatures&uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL) != 0
+}
+
+// Editions reports whether the plugin supports editions.
+func (c Capabilities) Editions() bool {
+	return c.SupportedFeatures&uint64(pluginpb.CodeGeneratorResponse_FEATURE_SUPPORTS_EDITIONS) != 0
+}
+
+// ProbeCapabilities runs the canary request against the plugin version and records the declared capabilities.
+// Capabilities are recorded even if the plugin reports an error, protoc reads them from such responses too.
+func (c *Core) ProbeCapabilities(ctx context.Context, info PluginInfo) (*Capabilities, error) {
+	plugin, err := c.registry.Get(ctx, PluginRef{Group: info.Group, Name: info.Name, Version: info.Version})
+	if err != nil {
+		return nil, fmt.Errorf("c.registry.Get: %w", err)
+	}
+
+	err = c.verifier.Verify(ctx, *plugin.Info(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("c.verifier.Verify: %w", err)
+	}
+
+	resp, err := plugin.Generate(ctx, canaryRequest())
+	if err != nil {
+		return nil, fmt.Errorf("plugin.Generate: %w", err)
+	}
+
+	capabilities := Capabilities{
+		SupportedFeatures: resp.GetSupportedFeatures(),
+		MinimumEdition:    descriptorpb.Edition(resp.GetMinimumEdition()),
+		MaximumEdition:    descriptorpb.Edition(resp.GetMaximumEdition()),
+		CheckedAt:         time.Now(),
+	}
+
+	err = c.registry.SaveCapabilities(ctx, info.ID, capabilities)
+	if err != nil {
+		return nil, fmt.Errorf("c.registry.SaveCapabilities: %w", err)
+	}
+
+	return &capabilities, nil
+}
+
+// ProbeNewCapabilities probes capabilities of every plugin version which wasn't probed yet.
+// A failed probe is logged and retried in the next round.
+func (c *Core) ProbeNewCapabilities(ctx context.Context) error {
+	plugins, err := c.registry.List(ctx, PluginFilter{})
+	if err != nil {
+		return fmt.Errorf("c.registry.List: %w", err)
+	}
+
+	log := logger.FromContext(ctx)
+	for _, info := range plugins {
+		if info.Capabilities != nil {
+			continue
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		name := info.Group + "/" + info.Name + ":" + info.Version
+		capabilities, err := c.ProbeCapabilities(ctx, info)
+		if err != nil {
+			log.Error("failed to probe plugin capabilities",
+				slog.String("plugin", name),
+				slog.String(logger.Error.String(), err.Error()),
+			)
+
+			continue
+		}
+
+		log.Info("plugin capabilities probed",
+			slog.String("plugin", name),
+			slog.Bool("proto3_optional", capabilities.Proto3Optional()),
+			slog.Bool("editions", capabilities.Editions()),
+			slog.String("minimum_edition", capabilities.MinimumEdition.String()),
+			slog.String("maximum_edition", capabilities.MaximumEdition.String()),
+		)
+	}
+
+	return nil
+}
+
+// checkCapabilities returns a ValidationError if files to generate use proto3 optional fields
+// or editions the plugin doesn't support, protoc would reject the generated code.
+// Field paths are relative to the CodeGeneratorRequest message.
+func checkCapabilities(req *pluginpb.CodeGeneratorRequest, capabilities *Capabilities) error {
+	if capabilities == nil {
+		return nil
+	}
+
+	toGenerate := make(map[string]bool, len(req.GetFileToGenerate()))
+	for _, name := range req.GetFileToGenerate() {
+		toGenerate[name] = true
+	}
+
+	var violations []FieldViolation
+	for i, file := range req.GetProtoFile() {
+		if !toGenerate[file.GetName()] {
+			continue
+		}
+
+		field := fmt.Sprintf("proto_file[%d]", i)
+
+		if file.GetSyntax() == "editions" {
+			if v, ok := checkEdition(field, file, capabilities); !ok {
+				violations = append(violations, v)
+			}
+		}
+
+		if !capabilities.Proto3Optional() {
+			if path := proto3OptionalField(file); path != "" {
+				violations = append(violations, FieldViolation{
+					Field:       field + "." + path + ".proto3_optional",
+					Description: fmt.Sprintf("%s uses proto3 optional fields, the plugin doesn't support them", file.GetName()),
+				})
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+
+	return nil
+}
+
+func checkEdition(field string, file *descriptorpb.FileDescriptorProto, capabilities *Capabilities) (FieldViolation, bool) {
+	edition := file.GetEdition()
+	violation := FieldViolation{Field: field + ".edition"}
+
+	switch {
+	case !capabilities.Editions():
+		violation.Description = fmt.Sprintf("%s uses %s, the plugin doesn't support editions", file.GetName(), edition)
+	case capabilities.MinimumEdition == descriptorpb.Edition_EDITION_UNKNOWN ||
+		capabilities.MaximumEdition == descriptorpb.Edition_EDITION_UNKNOWN:
+		violation.Description = fmt.Sprintf("%s uses %s, the plugin doesn't declare its editions range", file.GetName(), edition)
+	case edition < capabilities.MinimumEdition || edition > capabilities.MaximumEdition:
+		violation.Description = fmt.Sprintf("%s uses %s, the plugin supports %s to %s",
+			file.GetName(), edition, capabilities.MinimumEdition, capabilities.MaximumEdition)
+	default:
+		return FieldViolation{}, true
+	}
+
+	return violation, false
+}
+
+// proto3OptionalField returns the path of the first proto3 optional field of the file, empty if there is none.
+func proto3OptionalField(file *descriptorpb.FileDescriptorProto) string {
+	for i, ext := range file.GetExtension() {
+		if ext.GetProto3Optional() {
+			return fmt.Sprintf("extension[%d]", i)
+		}
+	}
+
+	for i, message := range file.GetMessageType() {
+		if path := proto3OptionalMessageField(message); path != "" {
+			return fmt.Sprintf("message_type[%d].%s", i, path)
+		}
+	}
+
+	return ""
+}
+
+func proto3OptionalMessageField(message *descriptorpb.DescriptorProto) string {
+	for i, field := range message.GetField() {
+		if field.GetProto3Optional() {
+			return fmt.Sprintf("field[%d]", i)
+		}
+	}
+
+	for i, ext := range message.GetExtension() {
+		if ext.GetProto3Optional() {
+			return fmt.Sprintf("extension[%d]", i)
+		}
+	}
+
+	for i, nested := range message.GetNestedType() {
+		if path := proto3OptionalMessageField(nested); path != "" {
+			return fmt.Sprintf("nested_type[%d].%s", i, path)
+		}
+	}
+
+	return ""
+}
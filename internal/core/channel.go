@@ -0,0 +1,124 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+type (
+	// Channel is a named release track of a plugin (e.g., "stable") pointing to one of its versions.
+	Channel struct {
+		Group    string
+		Name     string
+		Channel  string
+		PluginID uuid.UUID
+		Version  string
+		// UpdatedAt is the time of the latest move.
+		UpdatedAt time.Time
+	}
+
+	// ChannelMove is an entry of the channel history.
+	ChannelMove struct {
+		Channel string
+		Version string
+		// Previous is the version the channel pointed to before the move, empty if the channel was created.
+		Previous string
+		Reason   string
+		MovedAt  time.Time
+	}
+)
+
+// MoveChannel points the channel to the plugin version, the version may be given as "latest",
+// a version constraint or another channel, it is resolved first. Only available versions can be used.
+func (c *Core) MoveChannel(ctx context.Context, pluginName, channel, reason string) (*ChannelMove, error) {
+	err := ValidateChannel(channel)
+	if err != nil {
+		return nil, fmt.Errorf("ValidateChannel: %w", err)
+	}
+
+	ref, err := ParsePluginRef(pluginName)
+	if err != nil {
+		return nil, fmt.Errorf("ParsePluginRef: %w", err)
+	}
+
+	ref, err = c.resolveVersion(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("c.resolveVersion: %w", err)
+	}
+
+	plugin, err := c.registry.Get(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("c.registry.Get: %w", err)
+	}
+
+	info := *plugin.Info(ctx)
+	if info.Status != PluginAvailable {
+		return nil, fmt.Errorf("%w: %s/%s:%s is %s", ErrPluginUnavailable, info.Group, info.Name, info.Version, info.Status)
+	}
+
+	move, err := c.registry.MoveChannel(ctx, info, channel, reason)
+	if err != nil {
+		return nil, fmt.Errorf("c.registry.MoveChannel: %w", err)
+	}
+
+	return move, nil
+}
+
+// RollbackChannel moves the channel of the plugin ("<group>/<name>") back to the version it pointed to
+// before its latest move. The version must still be available.
+func (c *Core) RollbackChannel(ctx context.Context, pluginPath, channel, reason string) (*ChannelMove, error) {
+	err := ValidateChannel(channel)
+	if err != nil {
+		return nil, fmt.Errorf("ValidateChannel: %w", err)
+	}
+
+	ref, err := ParsePluginPath(pluginPath)
+	if err != nil {
+		return nil, fmt.Errorf("ParsePluginPath: %w", err)
+	}
+
+	move, err := c.registry.RollbackChannel(ctx, ref.Group, ref.Name, channel, reason)
+	if err != nil {
+		return nil, fmt.Errorf("c.registry.RollbackChannel: %w", err)
+	}
+
+	return move, nil
+}
+
+// Channels returns channels of the plugin ("<group>/<name>"), sorted by name.
+func (c *Core) Channels(ctx context.Context, pluginPath string) ([]Channel, error) {
+	ref, err := ParsePluginPath(pluginPath)
+	if err != nil {
+		return nil, fmt.Errorf("ParsePluginPath: %w", err)
+	}
+
+	channels, err := c.registry.Channels(ctx, ref.Group, ref.Name)
+	if err != nil {
+		return nil, fmt.Errorf("c.registry.Channels: %w", err)
+	}
+
+	return channels, nil
+}
+
+// ChannelHistory returns moves of the channel of the plugin ("<group>/<name>"), the latest first.
+func (c *Core) ChannelHistory(ctx context.Context, pluginPath, channel string) ([]ChannelMove, error) {
+	err := ValidateChannel(channel)
+	if err != nil {
+		return nil, fmt.Errorf("ValidateChannel: %w", err)
+	}
+
+	ref, err := ParsePluginPath(pluginPath)
+	if err != nil {
+		return nil, fmt.Errorf("ParsePluginPath: %w", err)
+	}
+
+	history, err := c.registry.ChannelHistory(ctx, ref.Group, ref.Name, channel)
+	if err != nil {
+		return nil, fmt.Errorf("c.registry.ChannelHistory: %w", err)
+	}
+
+	return history, nil
+}
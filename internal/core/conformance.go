@@ -0,0 +1,243 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// conformanceRuns is the number of canary runs compared by the determinism check.
+const conformanceRuns = 3
+
+// conformancePadding is the size of the comment which makes the stdin check request large.
+const conformancePadding = 4 << 20
+
+var errNonConformant = errors.New("plugin is not conformant")
+
+// ConformanceCheck is a requirement of the protoc plugin protocol.
+type ConformanceCheck string
+
+// Conformance checks.
+const (
+	// CheckValidResponse requires a valid CodeGeneratorResponse with safe file names and known features.
+	CheckValidResponse ConformanceCheck = "valid_response"
+	// CheckReadsStdin requires the whole request to be read, even a large one.
+	CheckReadsStdin ConformanceCheck = "reads_stdin"
+	// CheckErrorField requires errors to be reported in CodeGeneratorResponse.error instead of crashing.
+	CheckErrorField ConformanceCheck = "error_field"
+	// CheckProto3Optional requires FEATURE_PROTO3_OPTIONAL to be declared if proto3 optional fields are supported.
+	CheckProto3Optional ConformanceCheck = "proto3_optional"
+	// CheckEditions requires a consistent editions range if FEATURE_SUPPORTS_EDITIONS is declared.
+	CheckEditions ConformanceCheck = "editions"
+	// CheckDeterministic requires identical responses to identical requests.
+	CheckDeterministic ConformanceCheck = "deterministic"
+)
+
+// ConformanceResult is the result of a conformance check.
+type ConformanceResult struct {
+	Check  ConformanceCheck
+	Passed bool
+	// Advisory checks report problems protoc tolerates, they don't make the plugin non-conformant.
+	Advisory bool
+	// Message describes the failure or a notable pass, may be empty.
+	Message string
+}
+
+// Conformant reports whether none of the required checks failed.
+func Conformant(results []ConformanceResult) bool {
+	for _, result := range results {
+		if !result.Passed && !result.Advisory {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CheckConformance runs requests against a plugin to check it behaves like a well-formed protoc plugin.
+// Registry candidates run without network, so a plugin which needs the network fails valid_response.
+func CheckConformance(ctx context.Context, plugin Plugin) []ConformanceResult {
+	canary, err := plugin.Generate(ctx, canaryRequest())
+	if err != nil {
+		err = fmt.Errorf("plugin.Generate: %w", err)
+	} else {
+		err = validateCanary(canary)
+	}
+
+	results := []ConformanceResult{
+		result(CheckValidResponse, err),
+		checkReadsStdin(ctx, plugin),
+		checkErrorField(ctx, plugin),
+	}
+
+	if err != nil {
+		for _, check := range []ConformanceCheck{CheckProto3Optional, CheckEditions, CheckDeterministic} {
+			results = append(results, ConformanceResult{Check: check, Message: "skipped, the canary request failed"})
+		}
+
+		return results
+	}
+
+	return append(results,
+		checkProto3Optional(ctx, plugin),
+		checkEditions(ctx, plugin, canary),
+		checkDeterministic(ctx, plugin, canary),
+	)
+}
+
+func result(check ConformanceCheck, err error) ConformanceResult {
+	if err != nil {
+		return ConformanceResult{Check: check, Message: err.Error()}
+	}
+
+	return ConformanceResult{Check: check, Passed: true}
+}
+
+// validateCanary checks the response to the canary request.
+func validateCanary(resp *pluginpb.CodeGeneratorResponse) error {
+	if msg := resp.GetError(); msg != "" {
+		return fmt.Errorf("%w: plugin reported: %s", errNonConformant, msg)
+	}
+
+	err := validateGeneratedFiles(resp)
+	if err != nil {
+		return fmt.Errorf("validateGeneratedFiles: %w", err)
+	}
+
+	known := uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL | pluginpb.CodeGeneratorResponse_FEATURE_SUPPORTS_EDITIONS)
+	if unknown := resp.GetSupportedFeatures() &^ known; unknown != 0 {
+		return fmt.Errorf("%w: unknown supported_features bits %#x", errNonConformant, unknown)
+	}
+
+	if len(resp.GetFile()) == 0 {
+		return fmt.Errorf("%w: no files generated for %s", errNonConformant, canaryFile)
+	}
+
+	return nil
+}
+
+// checkReadsStdin sends a request with a large file which isn't generated,
+// a plugin which stops reading early can't parse it.
+func checkReadsStdin(ctx context.Context, plugin Plugin) ConformanceResult {
+	req := canaryRequest()
+	req.ProtoFile = append([]*descriptorpb.FileDescriptorProto{{
+		Name:    proto.String("easyp/canary/v1/padding.proto"),
+		Package: proto.String("easyp.canary.v1"),
+		Syntax:  proto.String("proto3"),
+		Options: req.ProtoFile[0].Options,
+		SourceCodeInfo: &descriptorpb.SourceCodeInfo{
+			Location: []*descriptorpb.SourceCodeInfo_Location{{
+				Path:            []int32{},
+				Span:            []int32{0, 0, 0},
+				LeadingComments: proto.String(strings.Repeat("x", conformancePadding)),
+			}},
+		},
+	}}, req.ProtoFile...)
+
+	resp, err := plugin.Generate(ctx, req)
+	switch {
+	case err != nil:
+		return result(CheckReadsStdin, fmt.Errorf("plugin.Generate: %w", err))
+	case resp.GetError() != "":
+		return result(CheckReadsStdin, fmt.Errorf("%w: plugin reported: %s", errNonConformant, resp.GetError()))
+	}
+
+	return result(CheckReadsStdin, nil)
+}
+
+// checkErrorField sends a request to generate a file missing in proto_file.
+// protoc never sends such a request, so the check is advisory:
+// plugins built with protogen exit with code 1 instead of reporting the error.
+func checkErrorField(ctx context.Context, plugin Plugin) ConformanceResult {
+	req := canaryRequest()
+	req.FileToGenerate = []string{"easyp/canary/v1/missing.proto"}
+
+	resp, err := plugin.Generate(ctx, req)
+	switch {
+	case err != nil:
+		err = fmt.Errorf("plugin.Generate: %w", err)
+	case resp.GetError() == "":
+		err = fmt.Errorf("%w: generated code for a file missing in proto_file without an error", errNonConformant)
+	}
+
+	res := result(CheckErrorField, err)
+	res.Advisory = true
+	return res
+}
+
+// checkProto3Optional sends a proto3 optional field, protoc rejects the output
+// of plugins which don't declare FEATURE_PROTO3_OPTIONAL.
+func checkProto3Optional(ctx context.Context, plugin Plugin) ConformanceResult {
+	req := canaryRequest()
+	message := req.ProtoFile[0].MessageType[0]
+	message.Field[0].Proto3Optional = proto.Bool(true)
+	message.Field[0].OneofIndex = proto.Int32(0)
+	message.OneofDecl = []*descriptorpb.OneofDescriptorProto{{Name: proto.String("_id")}}
+
+	resp, err := plugin.Generate(ctx, req)
+	if err != nil {
+		return result(CheckProto3Optional, fmt.Errorf("plugin.Generate: %w", err))
+	}
+
+	declared := resp.GetSupportedFeatures()&uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL) != 0
+	switch {
+	case declared && resp.GetError() != "":
+		err = fmt.Errorf("%w: FEATURE_PROTO3_OPTIONAL is declared, but the plugin reported: %s", errNonConformant, resp.GetError())
+	case !declared && resp.GetError() == "":
+		err = fmt.Errorf("%w: proto3 optional fields are generated, but FEATURE_PROTO3_OPTIONAL isn't declared", errNonConformant)
+	}
+
+	return result(CheckProto3Optional, err)
+}
+
+// checkEditions validates the declared editions range and sends an edition 2023 file if it is in the range.
+func checkEditions(ctx context.Context, plugin Plugin, canary *pluginpb.CodeGeneratorResponse) ConformanceResult {
+	if canary.GetSupportedFeatures()&uint64(pluginpb.CodeGeneratorResponse_FEATURE_SUPPORTS_EDITIONS) == 0 {
+		return ConformanceResult{Check: CheckEditions, Passed: true, Message: "editions aren't supported"}
+	}
+
+	minimum, maximum := descriptorpb.Edition(canary.GetMinimumEdition()), descriptorpb.Edition(canary.GetMaximumEdition())
+	switch {
+	case minimum == descriptorpb.Edition_EDITION_UNKNOWN || maximum == descriptorpb.Edition_EDITION_UNKNOWN:
+		return result(CheckEditions, fmt.Errorf("%w: FEATURE_SUPPORTS_EDITIONS is declared without minimum_edition and maximum_edition", errNonConformant))
+	case minimum > maximum:
+		return result(CheckEditions, fmt.Errorf("%w: minimum_edition %s is after maximum_edition %s", errNonConformant, minimum, maximum))
+	case descriptorpb.Edition_EDITION_2023 < minimum || descriptorpb.Edition_EDITION_2023 > maximum:
+		return ConformanceResult{Check: CheckEditions, Passed: true, Message: fmt.Sprintf("editions %s to %s, EDITION_2023 isn't checked", minimum, maximum)}
+	}
+
+	req := canaryRequest()
+	req.ProtoFile[0].Syntax = proto.String("editions")
+	req.ProtoFile[0].Edition = descriptorpb.Edition_EDITION_2023.Enum()
+
+	resp, err := plugin.Generate(ctx, req)
+	switch {
+	case err != nil:
+		err = fmt.Errorf("plugin.Generate: %w", err)
+	case resp.GetError() != "":
+		err = fmt.Errorf("%w: EDITION_2023 is in the declared range, but the plugin reported: %s", errNonConformant, resp.GetError())
+	}
+
+	return result(CheckEditions, err)
+}
+
+// checkDeterministic repeats the canary request and compares the responses.
+func checkDeterministic(ctx context.Context, plugin Plugin, canary *pluginpb.CodeGeneratorResponse) ConformanceResult {
+	for range conformanceRuns - 1 {
+		resp, err := plugin.Generate(ctx, canaryRequest())
+		if err != nil {
+			return result(CheckDeterministic, fmt.Errorf("plugin.Generate: %w", err))
+		}
+
+		if !proto.Equal(resp, canary) {
+			return result(CheckDeterministic, fmt.Errorf("%w: responses to identical requests differ", errNonConformant))
+		}
+	}
+
+	return result(CheckDeterministic, nil)
+}
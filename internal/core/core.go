@@ -2,57 +2,212 @@
 package core
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"google.golang.org/protobuf/types/pluginpb"
 )
 
 // Core defines the interface for interacting with the plugin server.
 type Core struct {
 	metrics  Metrics
 	registry Registry
+	verifier Verifier
+	limits   Limits
+	breakers *breakers
+	shadows  chan struct{} // Semaphore of running shadow executions.
 }
 
 // New creates a new Core instance.
-func New(metrics Metrics, registry Registry) *Core {
+func New(metrics Metrics, registry Registry, verifier Verifier, limits Limits, breaker BreakerConfig) *Core {
 	return &Core{
 		metrics:  metrics,
 		registry: registry,
+		verifier: verifier,
+		limits:   limits,
+		breakers: newBreakers(breaker),
+		shadows:  make(chan struct{}, maxShadowRuns),
 	}
 }
 
 // Generate generates code by plugin.
 func (c *Core) Generate(ctx context.Context, req GenerateCodeRequest) (*GenerateCodeResponse, error) {
-	group, err := getGroup(req.PluginName)
+	plugin, info, err := c.prepare(ctx, req.PluginName, req.Payload)
 	if err != nil {
-		return nil, fmt.Errorf("getGroup: %w", err)
+		return nil, fmt.Errorf("c.prepare: %w", err)
 	}
 
-	name, version, err := getNameAndVersion(req.PluginName)
+	key := info.Group + "/" + info.Name + ":" + info.Version
+
+	_, err = c.breakers.allow(key)
 	if err != nil {
-		return nil, fmt.Errorf("getNameAndVersion: %w", err)
+		return nil, fmt.Errorf("c.breakers.allow: %w", err)
 	}
 
-	plugin, err := c.registry.Get(ctx, group, name, version)
-	if err != nil {
-		return nil, fmt.Errorf("c.registry.Get: %w", err)
+	generatedCode, duration, err := c.run(ctx, plugin, info, req.Payload)
+
+	state := c.breakers.record(key, err)
+	metricErr := c.metrics.CircuitState(ctx, info, state)
+	if metricErr != nil {
+		return nil, fmt.Errorf("c.metrics.CircuitState: %w", metricErr)
 	}
 
-	generatedCode, err := plugin.Generate(ctx, req.Payload)
 	if err != nil {
-		return nil, fmt.Errorf("plugin.Generate: %w", err)
+		return nil, fmt.Errorf("c.run: %w", err)
+	}
+
+	var determinism *DeterminismCheck
+	if req.VerifyDeterminism || plugin.VerifyDeterminism(ctx) {
+		determinism, err = c.verifyDeterminism(ctx, plugin, info, req.Payload, generatedCode)
+		if err != nil {
+			return nil, fmt.Errorf("c.verifyDeterminism: %w", err)
+		}
 	}
 
-	err = c.metrics.GenerateCode(ctx, *plugin.Info(ctx))
+	err = c.metrics.GenerateCode(ctx, info)
 	if err != nil {
 		return nil, fmt.Errorf("c.metrics.GenerateCode: %w", err)
 	}
 
+	c.startShadow(ctx, plugin, info, req.Payload, generatedCode)
+
+	return &GenerateCodeResponse{
+		Payload:     generatedCode,
+		Plugin:      info,
+		Duration:    duration,
+		Cached:      false,
+		Determinism: determinism,
+	}, nil
+}
+
+// run executes the request and checks the response, an error reported by the plugin
+// or invalid output fail the run like an execution failure.
+func (c *Core) run(
+	ctx context.Context,
+	plugin Plugin,
+	info PluginInfo,
+	payload *pluginpb.CodeGeneratorRequest,
+) (*pluginpb.CodeGeneratorResponse, time.Duration, error) {
+	start := time.Now()
+	generatedCode, err := c.execute(ctx, plugin, info, payload)
+	duration := time.Since(start)
+	if err != nil {
+		return nil, duration, fmt.Errorf("c.execute: %w", err)
+	}
+
+	err = checkResponse(info, generatedCode, duration)
+	if errors.Is(err, ErrUnsafeOutput) {
+		metricErr := c.metrics.UnsafeOutput(ctx, info)
+		if metricErr != nil {
+			return nil, duration, fmt.Errorf("c.metrics.UnsafeOutput: %w", metricErr)
+		}
+	}
+	if err != nil {
+		return nil, duration, fmt.Errorf("checkResponse: %w", err)
+	}
+
+	return generatedCode, duration, nil
+}
+
+// generate runs the request against the plugin like Generate but without side effects:
+// circuit breakers, metrics, determinism checks and shadow executions are skipped.
+func (c *Core) generate(ctx context.Context, pluginName string, payload *pluginpb.CodeGeneratorRequest) (*GenerateCodeResponse, error) {
+	plugin, info, err := c.prepare(ctx, pluginName, payload)
+	if err != nil {
+		return nil, fmt.Errorf("c.prepare: %w", err)
+	}
+
+	start := time.Now()
+	generatedCode, err := retry(ctx, plugin, payload, nil)
+	duration := time.Since(start)
+	if err != nil {
+		return nil, fmt.Errorf("retry: %w", err)
+	}
+
+	err = checkResponse(info, generatedCode, duration)
+	if err != nil {
+		return nil, fmt.Errorf("checkResponse: %w", err)
+	}
+
 	return &GenerateCodeResponse{
-		Payload: generatedCode,
+		Payload:  generatedCode,
+		Plugin:   info,
+		Duration: duration,
 	}, nil
 }
 
+// prepare checks the request, resolves the plugin it is sent to and checks the plugin can run it.
+func (c *Core) prepare(ctx context.Context, pluginName string, payload *pluginpb.CodeGeneratorRequest) (Plugin, PluginInfo, error) {
+	ref, err := ParsePluginRef(pluginName)
+	if err != nil {
+		return nil, PluginInfo{}, fmt.Errorf("ParsePluginRef: %w", err)
+	}
+
+	err = checkInputLimits(payload, c.limits.forPlugin(ref))
+	if err != nil {
+		return nil, PluginInfo{}, fmt.Errorf("checkInputLimits: %w", err)
+	}
+
+	err = validateCodeGeneratorRequest(payload)
+	if err != nil {
+		return nil, PluginInfo{}, fmt.Errorf("validateCodeGeneratorRequest: %w", err)
+	}
+
+	ref, err = c.resolveVersion(ctx, ref)
+	if err != nil {
+		return nil, PluginInfo{}, fmt.Errorf("c.resolveVersion: %w", err)
+	}
+
+	plugin, err := c.registry.Get(ctx, ref)
+	if err != nil {
+		return nil, PluginInfo{}, fmt.Errorf("c.registry.Get: %w", err)
+	}
+
+	info := *plugin.Info(ctx)
+
+	if info.Status != PluginAvailable {
+		return nil, PluginInfo{}, fmt.Errorf("%w: %s/%s:%s is %s", ErrPluginUnavailable, info.Group, info.Name, info.Version, info.Status)
+	}
+
+	err = checkCapabilities(payload, info.Capabilities)
+	if err != nil {
+		return nil, PluginInfo{}, fmt.Errorf("checkCapabilities: %w", err)
+	}
+
+	err = c.verifier.Verify(ctx, info)
+	if err != nil {
+		return nil, PluginInfo{}, fmt.Errorf("c.verifier.Verify: %w", err)
+	}
+
+	return plugin, info, nil
+}
+
+// checkResponse returns an ExecutionError if the plugin reported an error
+// and an error wrapping ErrUnsafeOutput if the generated files are unsafe.
+func checkResponse(info PluginInfo, resp *pluginpb.CodeGeneratorResponse, duration time.Duration) error {
+	if msg := resp.GetError(); msg != "" {
+		return &ExecutionError{
+			Failure:  FailurePluginError,
+			Plugin:   info.Group + "/" + info.Name + ":" + info.Version,
+			Message:  msg,
+			Duration: duration,
+		}
+	}
+
+	err := validateGeneratedFiles(resp)
+	if err != nil {
+		return fmt.Errorf("validateGeneratedFiles: %w", err)
+	}
+
+	return nil
+}
+
 // ListPlugins retrieves a list of plugins matching the filter.
 func (c *Core) ListPlugins(ctx context.Context, filter PluginFilter) ([]PluginInfo, error) {
 	plugins, err := c.registry.List(ctx, filter)
@@ -60,28 +215,124 @@ func (c *Core) ListPlugins(ctx context.Context, filter PluginFilter) ([]PluginIn
 		return nil, fmt.Errorf("c.registry.List: %w", err)
 	}
 
+	probes, err := c.registry.Probes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("c.registry.Probes: %w", err)
+	}
+
+	determinism, err := c.registry.Determinism(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("c.registry.Determinism: %w", err)
+	}
+
+	channels, err := c.registry.Channels(ctx, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("c.registry.Channels: %w", err)
+	}
+
+	channelsByID := make(map[uuid.UUID][]string)
+	for _, channel := range channels {
+		channelsByID[channel.PluginID] = append(channelsByID[channel.PluginID], channel.Channel)
+	}
+
+	for i := range plugins {
+		plugins[i].Circuit = c.breakers.state(plugins[i].Group + "/" + plugins[i].Name + ":" + plugins[i].Version)
+		if status, ok := probes[plugins[i].ID]; ok {
+			plugins[i].Probe = &status
+		}
+		if status, ok := determinism[plugins[i].ID]; ok {
+			plugins[i].Determinism = &status
+		}
+		plugins[i].Channels = channelsByID[plugins[i].ID]
+	}
+
+	slices.SortFunc(plugins, func(a, b PluginInfo) int {
+		return cmp.Or(
+			strings.Compare(a.Group, b.Group),
+			strings.Compare(a.Name, b.Name),
+			CompareVersions(a.Version, b.Version),
+		)
+	})
+
 	return plugins, nil
 }
 
-func getGroup(pluginName string) (string, error) {
-	splitArray := strings.Split(pluginName, "/")
-	if len(splitArray) != 2 {
-		return "", fmt.Errorf("%w: %s", ErrInvalidPluginName, pluginName)
+// PluginConfig returns the effective configuration of the plugin and the layers it was merged from.
+func (c *Core) PluginConfig(ctx context.Context, pluginName string) (*EffectiveConfig, error) {
+	ref, err := ParsePluginRef(pluginName)
+	if err != nil {
+		return nil, fmt.Errorf("ParsePluginRef: %w", err)
+	}
+
+	ref, err = c.resolveVersion(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("c.resolveVersion: %w", err)
+	}
+
+	cfg, err := c.registry.EffectiveConfig(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("c.registry.EffectiveConfig: %w", err)
 	}
 
-	return splitArray[0], nil
+	return cfg, nil
 }
 
-func getNameAndVersion(pluginName string) (string, string, error) {
-	splitArray := strings.Split(pluginName, "/")
-	if len(splitArray) != 2 {
-		return "", "", fmt.Errorf("%w: %s", ErrInvalidPluginName, pluginName)
+// OpenCircuits returns plugins ("<group>/<name>:<version>") whose circuit breakers are open.
+func (c *Core) OpenCircuits() []string {
+	return c.breakers.open()
+}
+
+// resolveVersion replaces "latest" and version constraints in the reference with the highest matching
+// registered version. Prereleases are skipped unless the constraint explicitly allows them.
+// Other references, exact versions and channel names, are returned as is.
+func (c *Core) resolveVersion(ctx context.Context, ref PluginRef) (PluginRef, error) {
+	if ref.Version != Latest && !ref.IsConstraint() {
+		return ref, nil
+	}
+
+	var constraint *Constraint
+	if ref.IsConstraint() {
+		parsed, err := ParseConstraint(ref.Version)
+		if err != nil {
+			return PluginRef{}, fmt.Errorf("ParseConstraint: %w: %w", ErrInvalidPluginName, err)
+		}
+		constraint = &parsed
+	}
+
+	plugins, err := c.registry.List(ctx, PluginFilter{Group: ref.Group, Name: ref.Name})
+	if err != nil {
+		return PluginRef{}, fmt.Errorf("c.registry.List: %w", err)
+	}
+
+	var best *Version
+	bestRaw := ""
+	for _, p := range plugins {
+		if p.Status != PluginAvailable {
+			continue
+		}
+
+		v, err := ParseVersion(p.Version)
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case constraint == nil && v.IsPrerelease():
+			continue
+		case constraint != nil && !constraint.Check(v):
+			continue
+		}
+
+		if best == nil || v.Compare(*best) > 0 {
+			best = &v
+			bestRaw = p.Version
+		}
 	}
 
-	nameVersion := strings.Split(splitArray[1], ":")
-	if len(nameVersion) != 2 {
-		return "", "", fmt.Errorf("%w: %s", ErrInvalidPluginName, pluginName)
+	if best == nil {
+		return PluginRef{}, fmt.Errorf("%w: no version of %s/%s matches %q", ErrNotFound, ref.Group, ref.Name, ref.Version)
 	}
 
-	return nameVersion[0], nameVersion[1], nil
+	ref.Version = bestRaw
+	return ref, nil
 }
@@ -0,0 +1,115 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/sipki-tech/dev-platform/logger"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+type (
+	// DeterminismCheck is the result of running a request twice and comparing the responses.
+	DeterminismCheck struct {
+		Deterministic bool
+		// Files lists generated files which differ between the runs, sorted.
+		// "error" and "supported_features" stand for the response fields of the same name.
+		Files     []string
+		CheckedAt time.Time
+	}
+
+	// DeterminismStatus summarizes determinism checks of a plugin version.
+	DeterminismStatus struct {
+		Checks           int64
+		Nondeterministic int64
+		// Files lists files which differed in the latest non-deterministic check.
+		Files     []string
+		CheckedAt time.Time
+		// NondeterministicAt is the time of the latest non-deterministic check, zero if there was none.
+		NondeterministicAt time.Time
+	}
+)
+
+// verifyDeterminism runs the request again and compares the response with the first one.
+// The result is recorded in metrics and in the plugin metadata.
+func (c *Core) verifyDeterminism(
+	ctx context.Context,
+	plugin Plugin,
+	info PluginInfo,
+	req *pluginpb.CodeGeneratorRequest,
+	first *pluginpb.CodeGeneratorResponse,
+) (*DeterminismCheck, error) {
+	second, err := c.execute(ctx, plugin, info, req)
+	if err != nil {
+		return nil, fmt.Errorf("c.execute: %w", err)
+	}
+
+	files := diffResponses(first, second)
+	check := &DeterminismCheck{
+		Deterministic: len(files) == 0,
+		Files:         files,
+		CheckedAt:     time.Now(),
+	}
+
+	if !check.Deterministic {
+		logger.FromContext(ctx).Warn("plugin output is not deterministic",
+			slog.String("plugin", info.Group+"/"+info.Name+":"+info.Version),
+			slog.Any("files", files),
+		)
+	}
+
+	err = c.metrics.Determinism(ctx, info, check.Deterministic)
+	if err != nil {
+		return nil, fmt.Errorf("c.metrics.Determinism: %w", err)
+	}
+
+	err = c.registry.SaveDeterminism(ctx, info.ID, *check)
+	if err != nil {
+		return nil, fmt.Errorf("c.registry.SaveDeterminism: %w", err)
+	}
+
+	return check, nil
+}
+
+// diffResponses returns the names of files which differ between the responses, sorted.
+func diffResponses(a, b *pluginpb.CodeGeneratorResponse) []string {
+	var diff []string
+	if a.GetError() != b.GetError() {
+		diff = append(diff, "error")
+	}
+
+	if a.GetSupportedFeatures() != b.GetSupportedFeatures() ||
+		a.GetMinimumEdition() != b.GetMinimumEdition() || a.GetMaximumEdition() != b.GetMaximumEdition() {
+		diff = append(diff, "supported_features")
+	}
+
+	// Files are compared in order, protoc applies insertion points in it.
+	changed := make(map[string]bool)
+	for i := range max(len(a.GetFile()), len(b.GetFile())) {
+		var fileA, fileB *pluginpb.CodeGeneratorResponse_File
+		if i < len(a.GetFile()) {
+			fileA = a.GetFile()[i]
+		}
+		if i < len(b.GetFile()) {
+			fileB = b.GetFile()[i]
+		}
+
+		if proto.Equal(fileA, fileB) {
+			continue
+		}
+
+		if fileA != nil {
+			changed[fileA.GetName()] = true
+		}
+		if fileB != nil {
+			changed[fileB.GetName()] = true
+		}
+	}
+
+	return append(diff, slices.Sorted(maps.Keys(changed))...)
+}
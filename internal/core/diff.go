@@ -0,0 +1,203 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// insertionPointMarker is the comment protoc looks for to apply an insertion point.
+const insertionPointMarker = "@@protoc_insertion_point(%s)"
+
+type (
+	// DiffCodeRequest asks to run a request against two plugins and compare the generated files.
+	DiffCodeRequest struct {
+		// Payload is sent to both plugins.
+		Payload *pluginpb.CodeGeneratorRequest
+		// Base and Head are plugin names in the GenerateCodeRequest.PluginName format.
+		Base string
+		Head string
+	}
+
+	// DiffCodeResponse is the comparison of files generated by two plugins.
+	DiffCodeResponse struct {
+		// Base and Head are the plugins which generated the files, with the versions resolved.
+		Base PluginInfo
+		Head PluginInfo
+		// Added, Removed and Changed list file names, sorted.
+		Added   []string
+		Removed []string
+		Changed []string
+		// Files are the differences of added, removed and changed files, sorted by name.
+		Files []FileDiff
+	}
+
+	// FileDiff is the difference of a generated file.
+	FileDiff struct {
+		Name   string
+		Change FileChange
+		// UnifiedDiff is the unified diff of the file content.
+		UnifiedDiff string
+	}
+
+	// FileChange is the kind of change of a generated file.
+	FileChange string
+)
+
+// File changes.
+const (
+	FileAdded   FileChange = "added"
+	FileRemoved FileChange = "removed"
+	FileChanged FileChange = "changed"
+)
+
+// Diff runs the request against both plugins, like Generate but without its side effects,
+// and compares the generated files. Insertion points are applied before files are compared.
+func (c *Core) Diff(ctx context.Context, req DiffCodeRequest) (*DiffCodeResponse, error) {
+	var (
+		wg               sync.WaitGroup
+		base, head       *GenerateCodeResponse
+		baseErr, headErr error
+	)
+	wg.Go(func() {
+		base, baseErr = c.generate(ctx, req.Base, req.Payload)
+	})
+	wg.Go(func() {
+		head, headErr = c.generate(ctx, req.Head, req.Payload)
+	})
+	wg.Wait()
+
+	if baseErr != nil {
+		return nil, fmt.Errorf("c.generate: %s: %w", req.Base, baseErr)
+	}
+
+	if headErr != nil {
+		return nil, fmt.Errorf("c.generate: %s: %w", req.Head, headErr)
+	}
+
+	baseFiles, err := applyInsertionPoints(base.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("applyInsertionPoints: %s: %w", req.Base, err)
+	}
+
+	headFiles, err := applyInsertionPoints(head.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("applyInsertionPoints: %s: %w", req.Head, err)
+	}
+
+	resp := &DiffCodeResponse{
+		Base: base.Plugin,
+		Head: head.Plugin,
+	}
+
+	names := slices.Sorted(maps.Keys(baseFiles))
+	for name := range headFiles {
+		if _, ok := baseFiles[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		before, inBase := baseFiles[name]
+		after, inHead := headFiles[name]
+
+		diff := FileDiff{Name: name}
+		switch {
+		case !inBase:
+			diff.Change = FileAdded
+			resp.Added = append(resp.Added, name)
+		case !inHead:
+			diff.Change = FileRemoved
+			resp.Removed = append(resp.Removed, name)
+		case before != after:
+			diff.Change = FileChanged
+			resp.Changed = append(resp.Changed, name)
+		default:
+			continue
+		}
+
+		diff.UnifiedDiff = unifiedDiff(name, before, after, inBase, inHead)
+		resp.Files = append(resp.Files, diff)
+	}
+
+	return resp, nil
+}
+
+// applyInsertionPoints returns the content of generated files by name the way protoc writes them:
+// content of files with an insertion point is inserted before the line with the marker
+// in a file generated earlier, indented like the marker.
+func applyInsertionPoints(resp *pluginpb.CodeGeneratorResponse) (map[string]string, error) {
+	files := make(map[string]string, len(resp.GetFile()))
+
+	name := ""
+	for i, file := range resp.GetFile() {
+		// An empty name continues the previous file.
+		if file.GetName() != "" {
+			name = file.GetName()
+		}
+
+		point := file.GetInsertionPoint()
+		if point == "" {
+			files[name] += file.GetContent()
+			continue
+		}
+
+		content, ok := files[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: file[%d]: insertion point %q into %q which wasn't generated", ErrGenerationFailed, i, point, name)
+		}
+
+		marker := strings.Index(content, fmt.Sprintf(insertionPointMarker, point))
+		if marker < 0 {
+			return nil, fmt.Errorf("%w: file[%d]: insertion point %q not found in %q", ErrGenerationFailed, i, point, name)
+		}
+
+		lineStart := strings.LastIndexByte(content[:marker], '\n') + 1
+		prefix := content[lineStart:marker]
+		indent := prefix[:len(prefix)-len(strings.TrimLeft(prefix, " \t"))]
+
+		var inserted strings.Builder
+		for line := range strings.Lines(file.GetContent()) {
+			if line != "\n" {
+				inserted.WriteString(indent)
+			}
+			inserted.WriteString(line)
+		}
+		if !strings.HasSuffix(file.GetContent(), "\n") && file.GetContent() != "" {
+			inserted.WriteByte('\n')
+		}
+
+		files[name] = content[:lineStart] + inserted.String() + content[lineStart:]
+	}
+
+	return files, nil
+}
+
+// unifiedDiff formats the difference of the file content as a unified diff,
+// inBase and inHead tell whether the file exists in each version.
+func unifiedDiff(name, before, after string, inBase, inHead bool) string {
+	from, to := "a/"+name, "b/"+name
+	if !inBase {
+		from = "/dev/null"
+	}
+	if !inHead {
+		to = "/dev/null"
+	}
+
+	if !utf8.ValidString(before) || !utf8.ValidString(after) {
+		return fmt.Sprintf("Binary files %s and %s differ\n", from, to)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", from, to)
+	writeHunks(&b, splitLines(before), splitLines(after))
+
+	return b.String()
+}
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"errors"
+	"maps"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+func TestApplyInsertionPoints(t *testing.T) {
+	t.Parallel()
+
+	type file struct {
+		name, point, content string
+	}
+
+	tests := []struct {
+		name  string
+		files []file
+		want  map[string]string
+	}{
+		{
+			name: "plain files",
+			files: []file{
+				{name: "a.go", content: "package a\n"},
+				{name: "b.go", content: "package b\n"},
+			},
+			want: map[string]string{"a.go": "package a\n", "b.go": "package b\n"},
+		},
+		{
+			name: "empty name continues the previous file",
+			files: []file{
+				{name: "a.go", content: "package a\n"},
+				{content: "\nvar x int\n"},
+			},
+			want: map[string]string{"a.go": "package a\n\nvar x int\n"},
+		},
+		{
+			name: "inserted before the marker with its indentation",
+			files: []file{
+				{name: "a.go", content: "func f() {\n\t// @@protoc_insertion_point(body)\n}\n"},
+				{name: "a.go", point: "body", content: "x := 1\n\ny := 2\n"},
+			},
+			want: map[string]string{"a.go": "func f() {\n\tx := 1\n\n\ty := 2\n\t// @@protoc_insertion_point(body)\n}\n"},
+		},
+		{
+			name: "space indentation and a missing newline",
+			files: []file{
+				{name: "a.py", content: "class A:\n    # @@protoc_insertion_point(class_scope)\n"},
+				{name: "a.py", point: "class_scope", content: "x = 1"},
+			},
+			want: map[string]string{"a.py": "class A:\n    x = 1\n    # @@protoc_insertion_point(class_scope)\n"},
+		},
+		{
+			name: "insertions keep their order",
+			files: []file{
+				{name: "a.go", content: "// @@protoc_insertion_point(imports)\n"},
+				{name: "a.go", point: "imports", content: "import \"a\"\n"},
+				{point: "imports", content: "import \"b\"\n"},
+			},
+			want: map[string]string{"a.go": "import \"a\"\nimport \"b\"\n// @@protoc_insertion_point(imports)\n"},
+		},
+		{
+			name: "empty insertion",
+			files: []file{
+				{name: "a.go", content: "// @@protoc_insertion_point(imports)\n"},
+				{name: "a.go", point: "imports"},
+			},
+			want: map[string]string{"a.go": "// @@protoc_insertion_point(imports)\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &pluginpb.CodeGeneratorResponse{}
+			for _, f := range tt.files {
+				resp.File = append(resp.File, &pluginpb.CodeGeneratorResponse_File{
+					Name:           proto.String(f.name),
+					InsertionPoint: proto.String(f.point),
+					Content:        proto.String(f.content),
+				})
+			}
+
+			got, err := applyInsertionPoints(resp)
+			if err != nil {
+				t.Fatalf("applyInsertionPoints: %v", err)
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Fatalf("applyInsertionPoints() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyInsertionPointsErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		resp *pluginpb.CodeGeneratorResponse
+	}{
+		{
+			name: "missing file",
+			resp: &pluginpb.CodeGeneratorResponse{File: []*pluginpb.CodeGeneratorResponse_File{
+				{Name: proto.String("a.go"), InsertionPoint: proto.String("imports"), Content: proto.String("x\n")},
+			}},
+		},
+		{
+			name: "missing marker",
+			resp: &pluginpb.CodeGeneratorResponse{File: []*pluginpb.CodeGeneratorResponse_File{
+				{Name: proto.String("a.go"), Content: proto.String("// @@protoc_insertion_point(imports)\n")},
+				{Name: proto.String("a.go"), InsertionPoint: proto.String("body"), Content: proto.String("x\n")},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := applyInsertionPoints(tt.resp)
+			if !errors.Is(err, ErrGenerationFailed) {
+				t.Fatalf("applyInsertionPoints() = %q, %v; want ErrGenerationFailed", got, err)
+			}
+		})
+	}
+}
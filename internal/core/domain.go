@@ -3,7 +3,9 @@ package core
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/gofrs/uuid/v5"
@@ -12,9 +14,21 @@ import (
 
 // Errors.
 var (
-	ErrNotFound          = errors.New("not found")
-	ErrInvalidPluginName = errors.New("invalid plugin name")
-	ErrGenerationFailed  = errors.New("code generation failed")
+	ErrNotFound            = errors.New("not found")
+	ErrInvalidPluginName   = errors.New("invalid plugin name")
+	ErrGenerationFailed    = errors.New("code generation failed")
+	ErrInvalidRequest      = errors.New("invalid request")
+	ErrDigestMismatch      = errors.New("digest mismatch")
+	ErrUntrustedPlugin     = errors.New("untrusted plugin")
+	ErrUnsafeSandbox       = errors.New("unsafe sandbox configuration")
+	ErrInvalidPluginConfig = errors.New("invalid plugin configuration")
+	ErrPluginTimeout       = errors.New("plugin execution timed out")
+	ErrOutputLimitExceeded = errors.New("plugin output limit exceeded")
+	ErrUnsafeOutput        = errors.New("unsafe plugin output")
+	ErrCircuitOpen         = errors.New("plugin circuit breaker is open")
+	ErrPluginUnavailable   = errors.New("plugin version is not available")
+	ErrInvalidChannel      = errors.New("invalid channel")
+	ErrAmbiguousPlugin     = errors.New("ambiguous plugin reference")
 )
 
 type (
@@ -23,16 +37,67 @@ type (
 		// GenerateCode records metrics for a code generation request.
 		// The pluginName parameter identifies which plugin was used (e.g., "grpc/go:v1.36.9").
 		GenerateCode(ctx context.Context, info PluginInfo) error
+		// UnsafeOutput records a response rejected because of unsafe generated file names.
+		UnsafeOutput(ctx context.Context, info PluginInfo) error
+		// Retry records a retry of a transient plugin execution failure.
+		Retry(ctx context.Context, info PluginInfo, failure ExecutionFailure) error
+		// CircuitState records the circuit breaker state of the plugin after an execution.
+		CircuitState(ctx context.Context, info PluginInfo, state CircuitState) error
+		// Probe records the result of a canary probe of the plugin.
+		Probe(ctx context.Context, info PluginInfo, status ProbeStatus) error
+		// Determinism records the result of a determinism check of the plugin.
+		Determinism(ctx context.Context, info PluginInfo, deterministic bool) error
+		// Shadow records the result of running a request against a candidate version of the plugin.
+		Shadow(ctx context.Context, info, candidate PluginInfo, result ShadowResult) error
 	}
 
 	// Registry provides access to available plugins.
 	Registry interface {
-		// Get retrieves a plugin by its identifier.
-		// The pluginName parameter specifies the plugin to retrieve (e.g., "protobuf/go:v1.36.9").
+		// Get retrieves a plugin by its reference (e.g., "protobuf/go:v1.36.9").
+		// "latest" and version constraints are resolved by Core before calling Get,
+		// channel names are resolved by the registry.
 		// Returns an error if the plugin is not found or cannot be loaded.
-		Get(ctx context.Context, pluginGroup, pluginName, pluginVersion string) (Plugin, error)
+		Get(ctx context.Context, ref PluginRef) (Plugin, error)
 		// List retrieves a list of plugins matching the filter.
 		List(ctx context.Context, filter PluginFilter) ([]PluginInfo, error)
+		// EffectiveConfig returns the configuration applied when the plugin is executed
+		// and the layers it was merged from. The reference is resolved like in Get.
+		EffectiveConfig(ctx context.Context, ref PluginRef) (*EffectiveConfig, error)
+		// SaveProbe stores the result of the latest canary probe of the plugin.
+		SaveProbe(ctx context.Context, pluginID uuid.UUID, status ProbeStatus) error
+		// Probes returns the latest canary probe results by plugin ID.
+		Probes(ctx context.Context) (map[uuid.UUID]ProbeStatus, error)
+		// Fixtures returns fixture cases attached to the plugin version.
+		Fixtures(ctx context.Context, pluginID uuid.UUID) ([]Fixture, error)
+		// SaveFixtureResults stores fixture results and the resulting status of the plugin version.
+		SaveFixtureResults(ctx context.Context, pluginID uuid.UUID, results []FixtureResult, status PluginStatus) error
+		// SaveCapabilities stores the capabilities declared by the plugin version.
+		SaveCapabilities(ctx context.Context, pluginID uuid.UUID, capabilities Capabilities) error
+		// SaveDeterminism adds the result of a determinism check to the plugin version summary.
+		SaveDeterminism(ctx context.Context, pluginID uuid.UUID, check DeterminismCheck) error
+		// Determinism returns determinism check summaries by plugin ID.
+		Determinism(ctx context.Context) (map[uuid.UUID]DeterminismStatus, error)
+		// SaveShadowResult adds the result of a shadow execution to the statistics of the plugin pair.
+		SaveShadowResult(ctx context.Context, result ShadowResult) error
+		// MoveChannel points the channel of the plugin to the version and records the move in the channel history.
+		MoveChannel(ctx context.Context, target PluginInfo, channel, reason string) (*ChannelMove, error)
+		// RollbackChannel points the channel back to the version it pointed to before its latest move,
+		// in one transaction with reading the history. An empty reason defaults to "rollback from <version>".
+		RollbackChannel(ctx context.Context, group, name, channel, reason string) (*ChannelMove, error)
+		// Channels returns channels of the plugin, all channels if group and name are empty.
+		Channels(ctx context.Context, group, name string) ([]Channel, error)
+		// ChannelHistory returns moves of the channel, the latest first.
+		ChannelHistory(ctx context.Context, group, name, channel string) ([]ChannelMove, error)
+		// PinDigests records the digest the tag points to for plugin versions registered without one
+		// and returns the pinned versions. Versions whose tag can't be resolved stay unpinned.
+		PinDigests(ctx context.Context) ([]PluginInfo, error)
+	}
+
+	// Verifier verifies that a plugin image can be trusted before it is executed.
+	Verifier interface {
+		// Verify returns an error wrapping ErrUntrustedPlugin if the plugin
+		// is unsigned or its signature is invalid.
+		Verify(ctx context.Context, info PluginInfo) error
 	}
 
 	// Plugin represents a code generator plugin that processes protobuf definitions.
@@ -43,21 +108,37 @@ type (
 		Generate(ctx context.Context, req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error)
 		// Info retrieves information about a plugin by its identifier.
 		Info(ctx context.Context) *PluginInfo
+		// RetryPolicy returns the policy for retrying transient execution failures.
+		RetryPolicy(ctx context.Context) RetryPolicy
+		// VerifyDeterminism reports whether every request must be checked for deterministic output.
+		VerifyDeterminism(ctx context.Context) bool
+		// ShadowPolicy returns the policy for running requests against a candidate version.
+		ShadowPolicy(ctx context.Context) ShadowPolicy
 	}
 
 	// GenerateCodeRequest represents an incoming request to generate code using a specific plugin.
 	GenerateCodeRequest struct {
 		// PluginName identifies the plugin to use for code generation.
-		// Format: "<language>:v<version>" (e.g., "go:v1.36.9", "python:v3.20.0").
+		// Format: "<group>/<name>[:<version>][@<digest>]" (e.g., "protocolbuffers/go:v1.36.9"), see PluginRef.
 		PluginName string
 		// Payload contains the protobuf code generation request with source files and parameters.
 		Payload *pluginpb.CodeGeneratorRequest
+		// VerifyDeterminism runs the plugin twice and compares the responses.
+		VerifyDeterminism bool
 	}
 
 	// GenerateCodeResponse wraps the response from a code generation operation.
 	GenerateCodeResponse struct {
 		// Payload contains the protobuf code generation response with generated files.
 		Payload *pluginpb.CodeGeneratorResponse
+		// Plugin is the plugin which produced the payload, with the version resolved.
+		Plugin PluginInfo
+		// Duration is the time spent executing the plugin.
+		Duration time.Duration
+		// Cached reports whether the payload was served from a cache.
+		Cached bool
+		// Determinism is the result of the determinism check, nil if the request wasn't checked.
+		Determinism *DeterminismCheck
 	}
 
 	// PluginInfo represents information about a plugin.
@@ -67,6 +148,67 @@ type (
 		Name      string
 		Version   string
 		CreatedAt time.Time
+		// Digest is the image content digest, empty if unknown.
+		Digest string
+		// Signature is a detached signature over SignedPayload, empty if the plugin is unsigned.
+		Signature []byte
+		// Circuit is the circuit breaker state, set by Core.ListPlugins.
+		Circuit CircuitState
+		// Probe is the latest canary probe result, set by Core.ListPlugins, nil if the plugin wasn't probed.
+		Probe *ProbeStatus
+		// Status tells whether the version can be used.
+		Status PluginStatus
+		// Capabilities are the declared features of the version, nil if they weren't probed yet.
+		Capabilities *Capabilities
+		// Determinism summarizes determinism checks, set by Core.ListPlugins, nil if the version wasn't checked.
+		Determinism *DeterminismStatus
+		// Channels pointing to the version, set by Core.ListPlugins, sorted.
+		Channels []string
+	}
+
+	// PluginStatus is the availability of a plugin version.
+	PluginStatus string
+
+	// ProbeStatus is the result of a canary probe.
+	ProbeStatus struct {
+		Healthy bool
+		Latency time.Duration
+		// Error describes the failure, empty if the probe succeeded.
+		Error     string
+		CheckedAt time.Time
+	}
+
+	// FieldViolation describes a single invalid field of a request.
+	FieldViolation struct {
+		// Field is a path to the invalid field (e.g., "proto_file[2].dependency[0]").
+		Field string
+		// Description explains why the field is invalid.
+		Description string
+	}
+
+	// ValidationError is returned when a request is structurally invalid.
+	// It wraps ErrInvalidRequest.
+	ValidationError struct {
+		Violations []FieldViolation
+	}
+
+	// ConfigSource identifies where a configuration layer comes from.
+	ConfigSource string
+
+	// ConfigLayer is a partial plugin configuration from a single source.
+	ConfigLayer struct {
+		Source ConfigSource
+		// Config is a JSON object, empty if the source has no configuration for the plugin.
+		Config json.RawMessage
+	}
+
+	// EffectiveConfig is the plugin configuration with all layers merged and defaults applied.
+	EffectiveConfig struct {
+		Plugin PluginInfo
+		// Config is the JSON encoded configuration used to run the plugin.
+		Config json.RawMessage
+		// Layers are ordered from the lowest to the highest priority.
+		Layers []ConfigLayer
 	}
 
 	// PluginFilter represents a filter for listing plugins.
@@ -74,5 +216,51 @@ type (
 		Group   string
 		Name    string
 		Version string
+		// Status, if set, selects versions with the status.
+		Status PluginStatus
 	}
 )
+
+// Plugin statuses.
+const (
+	// PluginAvailable versions can be used.
+	PluginAvailable PluginStatus = "available"
+	// PluginPending versions wait for their fixtures to run.
+	PluginPending PluginStatus = "pending"
+	// PluginFailed versions failed their fixtures.
+	PluginFailed PluginStatus = "failed"
+)
+
+// Configuration sources, from the lowest to the highest priority.
+const (
+	ConfigSourceServer  ConfigSource = "server"
+	ConfigSourceGroup   ConfigSource = "group"
+	ConfigSourcePlugin  ConfigSource = "plugin"
+	ConfigSourceVersion ConfigSource = "version"
+)
+
+// SignedPayload returns the data covered by the plugin signature: "<group>/<name>:<version>@<digest>".
+// Binding the reference to the digest keeps a signature from being reused for another plugin.
+func (p PluginInfo) SignedPayload() []byte {
+	return []byte(p.Group + "/" + p.Name + ":" + p.Version + "@" + p.Digest)
+}
+
+// Error implements error.
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		if v.Field == "" {
+			msgs = append(msgs, v.Description)
+			continue
+		}
+
+		msgs = append(msgs, v.Field+": "+v.Description)
+	}
+
+	return ErrInvalidRequest.Error() + ": " + strings.Join(msgs, "; ")
+}
+
+// Unwrap returns ErrInvalidRequest.
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidRequest
+}
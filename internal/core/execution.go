@@ -0,0 +1,66 @@
+package core
+
+import (
+	"time"
+)
+
+// ExecutionFailure is the kind of a plugin execution failure.
+type ExecutionFailure string
+
+// Plugin execution failures.
+const (
+	// FailurePluginError means the plugin reported an error in CodeGeneratorResponse.error.
+	FailurePluginError ExecutionFailure = "PLUGIN_ERROR"
+	// FailureExitCode means the plugin exited with a non-zero code.
+	FailureExitCode ExecutionFailure = "PLUGIN_EXIT_CODE"
+	// FailureOOMKilled means the plugin was killed for exceeding its memory limit.
+	FailureOOMKilled ExecutionFailure = "PLUGIN_OOM_KILLED"
+	// FailureTimeout means the plugin exceeded its execution time limit.
+	FailureTimeout ExecutionFailure = "PLUGIN_TIMEOUT"
+	// FailureImagePull means the plugin image could not be pulled.
+	FailureImagePull ExecutionFailure = "PLUGIN_IMAGE_PULL"
+	// FailureDaemonUnavailable means the container runtime could not be reached.
+	FailureDaemonUnavailable ExecutionFailure = "DAEMON_UNAVAILABLE"
+)
+
+// ExecutionError describes a failed plugin execution.
+// It wraps ErrGenerationFailed and the underlying error, if any.
+type ExecutionError struct {
+	Failure ExecutionFailure
+	// Plugin is the plugin reference (e.g., "grpc/go:v1.5.1").
+	Plugin string
+	// Message is a short description of the failure, may be empty if Err describes it.
+	Message string
+	// ExitCode is the container exit code, zero if the plugin didn't exit on its own.
+	ExitCode int
+	// Stderr is the beginning of the plugin stderr.
+	Stderr string
+	// Duration is the time spent executing the plugin.
+	Duration time.Duration
+	// Transient reports whether the failure is caused by the infrastructure and the execution may be retried.
+	Transient bool
+	// Err is the underlying error, may be nil.
+	Err error
+}
+
+// Error implements error.
+func (e *ExecutionError) Error() string {
+	msg := ErrGenerationFailed.Error() + ": " + e.Plugin
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+
+	return msg
+}
+
+// Unwrap returns ErrGenerationFailed and the underlying error.
+func (e *ExecutionError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{ErrGenerationFailed}
+	}
+
+	return []error{ErrGenerationFailed, e.Err}
+}
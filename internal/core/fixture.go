@@ -0,0 +1,271 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/sipki-tech/dev-platform/logger"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+var errFixtureMismatch = errors.New("output doesn't match the fixture")
+
+type (
+	// Fixture is a test case of a plugin version: a request and the expected generated files.
+	Fixture struct {
+		ID      uuid.UUID
+		Name    string
+		Request *pluginpb.CodeGeneratorRequest
+		// Expected maps a generated file name to its expected content.
+		Expected map[string]ExpectedFile
+	}
+
+	// ExpectedFile is the expected content of a generated file,
+	// either literally or as a golden hash for large files.
+	ExpectedFile struct {
+		Content string
+		// SHA256 is the hex encoded SHA-256 of the content, it takes precedence over Content.
+		SHA256 string
+	}
+
+	// FixtureResult is the result of running a fixture.
+	FixtureResult struct {
+		FixtureID uuid.UUID
+		Name      string
+		Passed    bool
+		// Error describes the failure, empty if the fixture passed.
+		Error string
+		RunAt time.Time
+	}
+)
+
+// RunFixtures runs fixtures of the plugin version in the sandbox and records the results.
+// A version with fixtures becomes available if all of them pass and failed if the output of one
+// doesn't match or is unsafe. Other failures, e.g. the plugin couldn't be executed, are inconclusive,
+// they keep the status. A pending version without fixtures becomes available, others keep their status.
+func (c *Core) RunFixtures(ctx context.Context, info PluginInfo) ([]FixtureResult, error) {
+	fixtures, err := c.registry.Fixtures(ctx, info.ID)
+	if err != nil {
+		return nil, fmt.Errorf("c.registry.Fixtures: %w", err)
+	}
+
+	if len(fixtures) == 0 {
+		if info.Status != PluginPending {
+			return nil, nil
+		}
+
+		err = c.registry.SaveFixtureResults(ctx, info.ID, nil, PluginAvailable)
+		if err != nil {
+			return nil, fmt.Errorf("c.registry.SaveFixtureResults: %w", err)
+		}
+
+		return nil, nil
+	}
+
+	plugin, err := c.registry.Get(ctx, PluginRef{Group: info.Group, Name: info.Name, Version: info.Version})
+	if err != nil {
+		return nil, fmt.Errorf("c.registry.Get: %w", err)
+	}
+
+	err = c.verifier.Verify(ctx, *plugin.Info(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("c.verifier.Verify: %w", err)
+	}
+
+	status := PluginAvailable
+	inconclusive := false
+	results := make([]FixtureResult, 0, len(fixtures))
+	for _, fixture := range fixtures {
+		result := FixtureResult{
+			FixtureID: fixture.ID,
+			Name:      fixture.Name,
+			Passed:    true,
+			RunAt:     time.Now(),
+		}
+
+		err := c.runFixture(ctx, plugin, fixture)
+		switch {
+		case err == nil:
+		case errors.Is(err, errFixtureMismatch), errors.Is(err, ErrUnsafeOutput):
+			result.Passed = false
+			result.Error = err.Error()
+			status = PluginFailed
+		default:
+			result.Passed = false
+			result.Error = err.Error()
+			inconclusive = true
+		}
+
+		results = append(results, result)
+	}
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if status == PluginAvailable && inconclusive {
+		// Run the fixtures again next time.
+		status = info.Status
+	}
+
+	err = c.registry.SaveFixtureResults(ctx, info.ID, results, status)
+	if err != nil {
+		return nil, fmt.Errorf("c.registry.SaveFixtureResults: %w", err)
+	}
+
+	return results, nil
+}
+
+// RunPendingFixtures runs fixtures of every pending plugin version.
+func (c *Core) RunPendingFixtures(ctx context.Context) error {
+	plugins, err := c.registry.List(ctx, PluginFilter{Status: PluginPending})
+	if err != nil {
+		return fmt.Errorf("c.registry.List: %w", err)
+	}
+
+	log := logger.FromContext(ctx)
+	for _, info := range plugins {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		results, err := c.RunFixtures(ctx, info)
+		if err != nil {
+			log.Error("failed to run plugin fixtures",
+				slog.String("plugin", info.Group+"/"+info.Name+":"+info.Version),
+				slog.String(logger.Error.String(), err.Error()),
+			)
+
+			continue
+		}
+
+		failed := 0
+		for _, result := range results {
+			if !result.Passed {
+				failed++
+			}
+		}
+
+		log.Info("plugin fixtures run",
+			slog.String("plugin", info.Group+"/"+info.Name+":"+info.Version),
+			slog.Int("fixtures", len(results)),
+			slog.Int("failed", failed),
+		)
+	}
+
+	return nil
+}
+
+// RegistrationRunner returns a service which processes newly registered plugin versions every interval:
+// it pins them to the digest of their tag, probes their capabilities and runs fixtures of pending versions.
+// 0 disables it.
+func (c *Core) RegistrationRunner(interval time.Duration) func(context.Context) error {
+	return func(ctx context.Context) error {
+		if interval <= 0 {
+			<-ctx.Done()
+			return nil
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		log := logger.FromContext(ctx)
+		for {
+			pinned, err := c.registry.PinDigests(ctx)
+			if err != nil && ctx.Err() == nil {
+				log.Error("failed to pin plugin digests", slog.String(logger.Error.String(), err.Error()))
+			}
+			for _, info := range pinned {
+				log.Info("plugin pinned to digest",
+					slog.String("plugin", info.Group+"/"+info.Name+":"+info.Version),
+					slog.String("digest", info.Digest),
+				)
+			}
+
+			err = c.ProbeNewCapabilities(ctx)
+			if err != nil && ctx.Err() == nil {
+				log.Error("failed to probe plugin capabilities", slog.String(logger.Error.String(), err.Error()))
+			}
+
+			err = c.RunPendingFixtures(ctx)
+			if err != nil && ctx.Err() == nil {
+				log.Error("failed to run plugin fixtures", slog.String(logger.Error.String(), err.Error()))
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	}
+}
+
+// runFixture runs the fixture request with retries of transient failures. The error wraps errFixtureMismatch
+// or ErrUnsafeOutput if the plugin generated the wrong output.
+func (c *Core) runFixture(ctx context.Context, plugin Plugin, fixture Fixture) error {
+	resp, err := c.execute(ctx, plugin, *plugin.Info(ctx), fixture.Request)
+	if err != nil {
+		return fmt.Errorf("c.execute: %w", err)
+	}
+
+	if msg := resp.GetError(); msg != "" {
+		return fmt.Errorf("%w: plugin reported: %s", errFixtureMismatch, msg)
+	}
+
+	err = validateGeneratedFiles(resp)
+	if err != nil {
+		return fmt.Errorf("validateGeneratedFiles: %w", err)
+	}
+
+	files, err := applyInsertionPoints(resp)
+	if err != nil {
+		return fmt.Errorf("%w: applyInsertionPoints: %w", errFixtureMismatch, err)
+	}
+
+	return compareFiles(files, fixture.Expected)
+}
+
+// compareFiles reports every generated file which is missing, unexpected or differs from the fixture.
+// Files are compared by name after insertion points are applied, the way protoc writes them.
+func compareFiles(files map[string]string, expected map[string]ExpectedFile) error {
+	var problems []string
+
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		content := files[name]
+
+		want, ok := expected[name]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("%s: unexpected file", name))
+		case want.SHA256 != "":
+			sum := sha256.Sum256([]byte(content))
+			if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, want.SHA256) {
+				problems = append(problems, fmt.Sprintf("%s: sha256 is %s, expected %s", name, got, want.SHA256))
+			}
+		case content != want.Content:
+			problems = append(problems, fmt.Sprintf("%s: content differs", name))
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(expected)) {
+		if _, ok := files[name]; !ok {
+			problems = append(problems, fmt.Sprintf("%s: not generated", name))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", errFixtureMismatch, strings.Join(problems, "; "))
+	}
+
+	return nil
+}
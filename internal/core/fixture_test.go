@@ -0,0 +1,146 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// fixtureRegistry serves one fixture and keeps the saved status.
+type fixtureRegistry struct {
+	stubRegistry
+
+	fixtures []Fixture
+	saved    *PluginStatus
+}
+
+func (r *fixtureRegistry) Fixtures(context.Context, uuid.UUID) ([]Fixture, error) {
+	return r.fixtures, nil
+}
+
+func (r *fixtureRegistry) SaveFixtureResults(_ context.Context, _ uuid.UUID, _ []FixtureResult, status PluginStatus) error {
+	r.saved = &status
+	return nil
+}
+
+func TestRunFixturesStatus(t *testing.T) {
+	t.Parallel()
+
+	file := func(name, content string) *pluginpb.CodeGeneratorResponse_File {
+		return &pluginpb.CodeGeneratorResponse_File{Name: proto.String(name), Content: proto.String(content)}
+	}
+
+	tests := []struct {
+		name string
+		resp *pluginpb.CodeGeneratorResponse
+		err  error
+		want PluginStatus
+	}{
+		{
+			name: "match",
+			resp: &pluginpb.CodeGeneratorResponse{File: []*pluginpb.CodeGeneratorResponse_File{file("a.pb.go", "package a\n")}},
+			want: PluginAvailable,
+		},
+		{
+			name: "mismatch",
+			resp: &pluginpb.CodeGeneratorResponse{File: []*pluginpb.CodeGeneratorResponse_File{file("a.pb.go", "package b\n")}},
+			want: PluginFailed,
+		},
+		{
+			name: "plugin error",
+			resp: &pluginpb.CodeGeneratorResponse{Error: proto.String("unsupported option")},
+			want: PluginFailed,
+		},
+		{
+			name: "unsafe output",
+			resp: &pluginpb.CodeGeneratorResponse{File: []*pluginpb.CodeGeneratorResponse_File{file("../a.pb.go", "package a\n")}},
+			want: PluginFailed,
+		},
+		{
+			name: "missing insertion point",
+			resp: &pluginpb.CodeGeneratorResponse{File: []*pluginpb.CodeGeneratorResponse_File{
+				file("a.pb.go", "package a\n"),
+				{Name: proto.String("a.pb.go"), InsertionPoint: proto.String("imports"), Content: proto.String("x")},
+			}},
+			want: PluginFailed,
+		},
+		{
+			name: "execution failure",
+			err:  &ExecutionError{Failure: FailureImagePull, Plugin: testPlugin},
+			want: PluginPending,
+		},
+		{
+			name: "digest mismatch",
+			err:  fmt.Errorf("p.digests.verify: %w", ErrDigestMismatch),
+			want: PluginPending,
+		},
+		{
+			name: "sandbox policy",
+			err:  fmt.Errorf("dockerArgs: %w", ErrUnsafeSandbox),
+			want: PluginPending,
+		},
+		{
+			name: "output limit",
+			err:  fmt.Errorf("cmd.Run: %w", ErrOutputLimitExceeded),
+			want: PluginPending,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			info := PluginInfo{ID: uuid.Must(uuid.NewV4()), Group: "grpc", Name: "go", Version: "v1.0.0", Status: PluginPending}
+			registry := &fixtureRegistry{
+				stubRegistry: stubRegistry{plugin: &stubPlugin{info: info, resp: tt.resp, err: tt.err}},
+				fixtures: []Fixture{{
+					Name: "simple",
+					Request: &pluginpb.CodeGeneratorRequest{
+						FileToGenerate: []string{"a.proto"},
+						ProtoFile:      []*descriptorpb.FileDescriptorProto{{Name: proto.String("a.proto")}},
+					},
+					Expected: map[string]ExpectedFile{"a.pb.go": {Content: "package a\n"}},
+				}},
+			}
+			c := New(&stubMetrics{}, registry, trustingVerifier{}, Limits{}, BreakerConfig{})
+
+			results, err := c.RunFixtures(context.Background(), info)
+			if err != nil {
+				t.Fatalf("RunFixtures: %v", err)
+			}
+			if len(results) != 1 || results[0].Passed != (tt.want == PluginAvailable) {
+				t.Fatalf("RunFixtures = %+v", results)
+			}
+			if registry.saved == nil || *registry.saved != tt.want {
+				t.Fatalf("saved status %v, want %s", registry.saved, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunFixturesCancelled(t *testing.T) {
+	t.Parallel()
+
+	info := PluginInfo{ID: uuid.Must(uuid.NewV4()), Group: "grpc", Name: "go", Version: "v1.0.0", Status: PluginPending}
+	registry := &fixtureRegistry{
+		stubRegistry: stubRegistry{plugin: &stubPlugin{info: info, err: context.Canceled}},
+		fixtures:     []Fixture{{Name: "simple", Request: &pluginpb.CodeGeneratorRequest{}}},
+	}
+	c := New(&stubMetrics{}, registry, trustingVerifier{}, Limits{}, BreakerConfig{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.RunFixtures(ctx, info)
+	if err == nil {
+		t.Fatal("RunFixtures succeeded, want the cancellation")
+	}
+	if registry.saved != nil {
+		t.Fatalf("saved status %s of a cancelled run", *registry.saved)
+	}
+}
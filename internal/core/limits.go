@@ -0,0 +1,95 @@
+package core
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+type (
+	// InputLimits bounds the size of a CodeGeneratorRequest. Zero means unlimited.
+	InputLimits struct {
+		// MaxRequestBytes is the maximum encoded size of the request.
+		MaxRequestBytes int
+		// MaxProtoFiles is the maximum number of proto_file entries.
+		MaxProtoFiles int
+		// MaxFilesToGenerate is the maximum number of file_to_generate entries.
+		MaxFilesToGenerate int
+		// MaxParameterLength is the maximum length of the parameter string.
+		MaxParameterLength int
+	}
+
+	// Limits are server-wide input limits with per-plugin overrides.
+	Limits struct {
+		// Input applies to every plugin.
+		Input InputLimits
+		// PluginInput overrides non-zero fields of Input for a plugin, the key is "<group>/<name>".
+		PluginInput map[string]InputLimits
+	}
+)
+
+// forPlugin returns the input limits for the plugin with overrides applied.
+func (l Limits) forPlugin(ref PluginRef) InputLimits {
+	limits := l.Input
+
+	override, ok := l.PluginInput[ref.Group+"/"+ref.Name]
+	if !ok {
+		return limits
+	}
+
+	if override.MaxRequestBytes != 0 {
+		limits.MaxRequestBytes = override.MaxRequestBytes
+	}
+	if override.MaxProtoFiles != 0 {
+		limits.MaxProtoFiles = override.MaxProtoFiles
+	}
+	if override.MaxFilesToGenerate != 0 {
+		limits.MaxFilesToGenerate = override.MaxFilesToGenerate
+	}
+	if override.MaxParameterLength != 0 {
+		limits.MaxParameterLength = override.MaxParameterLength
+	}
+
+	return limits
+}
+
+// checkInputLimits returns a ValidationError listing every exceeded limit.
+// Field paths are relative to the CodeGeneratorRequest message.
+func checkInputLimits(req *pluginpb.CodeGeneratorRequest, limits InputLimits) error {
+	var violations []FieldViolation
+
+	if size := proto.Size(req); limits.MaxRequestBytes > 0 && size > limits.MaxRequestBytes {
+		violations = append(violations, FieldViolation{
+			Field:       "",
+			Description: fmt.Sprintf("request is %d bytes, at most %d bytes allowed", size, limits.MaxRequestBytes),
+		})
+	}
+
+	if n := len(req.GetProtoFile()); limits.MaxProtoFiles > 0 && n > limits.MaxProtoFiles {
+		violations = append(violations, FieldViolation{
+			Field:       "proto_file",
+			Description: fmt.Sprintf("%d files, at most %d allowed", n, limits.MaxProtoFiles),
+		})
+	}
+
+	if n := len(req.GetFileToGenerate()); limits.MaxFilesToGenerate > 0 && n > limits.MaxFilesToGenerate {
+		violations = append(violations, FieldViolation{
+			Field:       "file_to_generate",
+			Description: fmt.Sprintf("%d files, at most %d allowed", n, limits.MaxFilesToGenerate),
+		})
+	}
+
+	if n := len(req.GetParameter()); limits.MaxParameterLength > 0 && n > limits.MaxParameterLength {
+		violations = append(violations, FieldViolation{
+			Field:       "parameter",
+			Description: fmt.Sprintf("%d characters, at most %d allowed", n, limits.MaxParameterLength),
+		})
+	}
+
+	if len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+
+	return nil
+}
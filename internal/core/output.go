@@ -0,0 +1,90 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+var (
+	errInvalidUTF8   = errors.New("name is not valid UTF-8")
+	errForbiddenChar = errors.New("forbidden character")
+	errAbsolutePath  = errors.New("absolute path")
+	errPathTraversal = errors.New("path escapes the output directory")
+	errNotCleanPath  = errors.New("path is not clean")
+)
+
+// reservedPathChars can't be used in generated file names, they are either path separators
+// or invalid on some file systems.
+const reservedPathChars = `\:*?"<>|`
+
+// validateGeneratedFiles checks that every generated file stays inside the output directory.
+// A file with an empty name continues the previous one, a file with an insertion point
+// may name a file generated earlier, so only complete files are checked for duplicates.
+func validateGeneratedFiles(resp *pluginpb.CodeGeneratorResponse) error {
+	var problems []string
+	seen := make(map[string]int, len(resp.GetFile()))
+
+	for i, file := range resp.GetFile() {
+		name := file.GetName()
+
+		if name == "" {
+			if i == 0 {
+				problems = append(problems, "file[0]: name is required")
+			}
+
+			continue
+		}
+
+		err := checkGeneratedPath(name)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("file[%d]: %q: %s", i, name, err))
+			continue
+		}
+
+		if file.GetInsertionPoint() != "" {
+			continue
+		}
+
+		if prev, ok := seen[name]; ok {
+			problems = append(problems, fmt.Sprintf("file[%d]: %q: duplicate of file[%d]", i, name, prev))
+			continue
+		}
+		seen[name] = i
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrUnsafeOutput, strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// checkGeneratedPath reports why the generated file name is unsafe.
+func checkGeneratedPath(name string) error {
+	if !utf8.ValidString(name) {
+		return errInvalidUTF8
+	}
+
+	for _, r := range name {
+		if !unicode.IsPrint(r) || strings.ContainsRune(reservedPathChars, r) {
+			return fmt.Errorf("%w %q", errForbiddenChar, r)
+		}
+	}
+
+	switch {
+	case path.IsAbs(name):
+		return errAbsolutePath
+	case name == ".." || strings.HasPrefix(name, "../") || strings.Contains(name, "/../") || strings.HasSuffix(name, "/.."):
+		return errPathTraversal
+	case path.Clean(name) != name:
+		return errNotCleanPath
+	}
+
+	return nil
+}
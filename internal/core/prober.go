@@ -0,0 +1,248 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/sipki-tech/dev-platform/logger"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+const defaultProbeTimeout = 30 * time.Second
+
+var errProbeFailed = errors.New("canary probe failed")
+
+// canaryFile is the proto file of the built-in canary request.
+const canaryFile = "easyp/canary/v1/canary.proto"
+
+// ProberConfig configures canary probing of registered plugins.
+type ProberConfig struct {
+	// Interval between probing rounds, 0 (the default) disables probing.
+	Interval time.Duration
+	// Timeout of a single probe, defaults to 30s.
+	Timeout time.Duration
+	// Plugins limits probing to "<group>/<name>" (any version) or "<group>/<name>:<version>" entries.
+	// Empty means every registered plugin.
+	Plugins []string
+}
+
+// Prober periodically runs a tiny built-in request against registered plugins
+// to detect broken plugins before users do.
+type Prober struct {
+	metrics  Metrics
+	registry Registry
+	verifier Verifier
+	cfg      ProberConfig
+
+	mu      sync.Mutex
+	failing map[uuid.UUID]string // Plugin ID to "<group>/<name>:<version>".
+}
+
+// NewProber creates a new Prober.
+func NewProber(metrics Metrics, registry Registry, verifier Verifier, cfg ProberConfig) *Prober {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultProbeTimeout
+	}
+
+	return &Prober{
+		metrics:  metrics,
+		registry: registry,
+		verifier: verifier,
+		cfg:      cfg,
+		failing:  make(map[uuid.UUID]string),
+	}
+}
+
+// Run probes plugins every interval until the context is done.
+func (p *Prober) Run(ctx context.Context) error {
+	if p.cfg.Interval <= 0 {
+		<-ctx.Done()
+		return nil
+	}
+
+	ticker := time.NewTicker(p.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		err := p.probeAll(ctx)
+		if err != nil && ctx.Err() == nil {
+			logger.FromContext(ctx).Error("canary probing failed", slog.String(logger.Error.String(), err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Failing returns plugins ("<group>/<name>:<version>") whose last probe failed, sorted.
+func (p *Prober) Failing() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	plugins := make([]string, 0, len(p.failing))
+	for _, name := range p.failing {
+		plugins = append(plugins, name)
+	}
+
+	slices.Sort(plugins)
+	return plugins
+}
+
+// probeAll probes the selected plugins one by one to keep the load low.
+func (p *Prober) probeAll(ctx context.Context) error {
+	plugins, err := p.registry.List(ctx, PluginFilter{})
+	if err != nil {
+		return fmt.Errorf("p.registry.List: %w", err)
+	}
+
+	plugins = slices.DeleteFunc(plugins, func(info PluginInfo) bool { return !p.selected(info) })
+
+	// Forget failures of versions which were removed, became unavailable or aren't probed anymore.
+	p.mu.Lock()
+	for id := range p.failing {
+		if !slices.ContainsFunc(plugins, func(info PluginInfo) bool { return info.ID == id }) {
+			delete(p.failing, id)
+		}
+	}
+	p.mu.Unlock()
+
+	for _, info := range plugins {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		status := p.probe(ctx, info)
+
+		name := info.Group + "/" + info.Name + ":" + info.Version
+		p.mu.Lock()
+		if status.Healthy {
+			delete(p.failing, info.ID)
+		} else {
+			p.failing[info.ID] = name
+		}
+		p.mu.Unlock()
+
+		err = p.registry.SaveProbe(ctx, info.ID, status)
+		if err != nil {
+			return fmt.Errorf("p.registry.SaveProbe: %w", err)
+		}
+
+		err = p.metrics.Probe(ctx, info, status)
+		if err != nil {
+			return fmt.Errorf("p.metrics.Probe: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (p *Prober) selected(info PluginInfo) bool {
+	if info.Status != PluginAvailable {
+		return false
+	}
+
+	if len(p.cfg.Plugins) == 0 {
+		return true
+	}
+
+	name := info.Group + "/" + info.Name
+	return slices.Contains(p.cfg.Plugins, name) || slices.Contains(p.cfg.Plugins, name+":"+info.Version)
+}
+
+// probe runs the canary request against the plugin.
+func (p *Prober) probe(ctx context.Context, info PluginInfo) ProbeStatus {
+	ctx, cancel := context.WithTimeout(ctx, p.cfg.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := p.run(ctx, info)
+	status := ProbeStatus{
+		Healthy:   err == nil,
+		Latency:   time.Since(start),
+		CheckedAt: start,
+	}
+	if err != nil {
+		status.Error = err.Error()
+	}
+
+	return status
+}
+
+func (p *Prober) run(ctx context.Context, info PluginInfo) error {
+	plugin, err := p.registry.Get(ctx, PluginRef{Group: info.Group, Name: info.Name, Version: info.Version})
+	if err != nil {
+		return fmt.Errorf("p.registry.Get: %w", err)
+	}
+
+	err = p.verifier.Verify(ctx, *plugin.Info(ctx))
+	if err != nil {
+		return fmt.Errorf("p.verifier.Verify: %w", err)
+	}
+
+	resp, err := plugin.Generate(ctx, canaryRequest())
+	if err != nil {
+		return fmt.Errorf("plugin.Generate: %w", err)
+	}
+
+	if msg := resp.GetError(); msg != "" {
+		return fmt.Errorf("%w: plugin reported: %s", errProbeFailed, msg)
+	}
+
+	return nil
+}
+
+// canaryRequest returns a minimal request with a single message and service,
+// with the options plugins commonly require to locate generated code.
+func canaryRequest() *pluginpb.CodeGeneratorRequest {
+	file := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String(canaryFile),
+		Package: proto.String("easyp.canary.v1"),
+		Syntax:  proto.String("proto3"),
+		Options: &descriptorpb.FileOptions{
+			GoPackage:         proto.String("easyp.tech/canary/v1;canaryv1"),
+			JavaPackage:       proto.String("tech.easyp.canary.v1"),
+			JavaMultipleFiles: proto.Bool(true),
+		},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("PingRequest"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("id"),
+				JsonName: proto.String("id"),
+				Number:   proto.Int32(1),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+			}},
+		}, {
+			Name: proto.String("PingResponse"),
+		}},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("CanaryAPI"),
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("Ping"),
+				InputType:  proto.String(".easyp.canary.v1.PingRequest"),
+				OutputType: proto.String(".easyp.canary.v1.PingResponse"),
+			}},
+		}},
+	}
+
+	return &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{canaryFile},
+		ProtoFile:      []*descriptorpb.FileDescriptorProto{file},
+		CompilerVersion: &pluginpb.Version{
+			Major: proto.Int32(5),
+			Minor: proto.Int32(29),
+			Patch: proto.Int32(0),
+		},
+	}
+}
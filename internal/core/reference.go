@@ -0,0 +1,161 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Latest is the version resolving to the most recent version of a plugin.
+const Latest = "latest"
+
+// PluginRef is a parsed plugin reference.
+//
+// Format: <group>/<name>[:<version>][@<digest>], at least one of version and digest is required.
+//
+// Examples:
+//   - protocolbuffers/go:v1.36.10
+//   - org/team/plugin:latest
+//   - grpc/go:stable
+//   - grpc/go:^v1.5
+//   - grpc/go:>=v1.5 <v2
+//   - grpc/go@sha256:<hex>
+//   - grpc/go:v1.5.1@sha256:<hex>
+type PluginRef struct {
+	// Group may consist of several path segments (e.g., "org/team").
+	Group string
+	// Name is the last path segment of the reference.
+	Name string
+	// Version is an exact version (e.g., "v1.36.10"), "latest", a version constraint (e.g., "^v1.36")
+	// or a channel name (e.g., "stable"). Empty if the reference is pinned by digest only.
+	Version string
+	// Digest is the image content digest (e.g., "sha256:<hex>"), empty if not pinned.
+	Digest string
+}
+
+var (
+	refPathSegment = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+	refVersion     = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+	refDigest      = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+	refChannel     = regexp.MustCompile(`^[a-z][a-z0-9-]{0,63}$`)
+)
+
+// ParsePluginRef parses a plugin reference, see PluginRef for the format.
+func ParsePluginRef(s string) (PluginRef, error) {
+	ref := PluginRef{}
+	rest := s
+
+	if i := strings.LastIndexByte(rest, '@'); i >= 0 {
+		ref.Digest = rest[i+1:]
+		rest = rest[:i]
+
+		if !refDigest.MatchString(ref.Digest) {
+			return PluginRef{}, fmt.Errorf("%w: %q: invalid digest %q", ErrInvalidPluginName, s, ref.Digest)
+		}
+	}
+
+	path := rest
+	if i := strings.LastIndexByte(rest, ':'); i >= 0 && !strings.Contains(rest[i+1:], "/") {
+		path = rest[:i]
+		ref.Version = rest[i+1:]
+
+		switch {
+		case ref.IsExactVersion():
+			// Semantic versions may carry build metadata (e.g., "v1.2.3+build"), refVersion rejects '+'.
+		case IsConstraint(ref.Version):
+			_, err := ParseConstraint(ref.Version)
+			if err != nil {
+				return PluginRef{}, fmt.Errorf("%w: %q: %w", ErrInvalidPluginName, s, err)
+			}
+		case !refVersion.MatchString(ref.Version):
+			return PluginRef{}, fmt.Errorf("%w: %q: invalid version %q", ErrInvalidPluginName, s, ref.Version)
+		}
+	}
+
+	if ref.Version == "" && ref.Digest == "" {
+		return PluginRef{}, fmt.Errorf("%w: %q: version or digest is required", ErrInvalidPluginName, s)
+	}
+
+	var err error
+	ref.Group, ref.Name, err = splitPluginPath(s, path)
+	if err != nil {
+		return PluginRef{}, err
+	}
+
+	return ref, nil
+}
+
+// ParsePluginPath parses a plugin reference without a version and a digest: <group>/<name>.
+func ParsePluginPath(s string) (PluginRef, error) {
+	group, name, err := splitPluginPath(s, s)
+	if err != nil {
+		return PluginRef{}, err
+	}
+
+	return PluginRef{Group: group, Name: name}, nil
+}
+
+// splitPluginPath splits the path part of the reference s into the group and the name.
+func splitPluginPath(s, path string) (group, name string, err error) {
+	segments := strings.Split(path, "/")
+	if len(segments) < 2 {
+		return "", "", fmt.Errorf("%w: %q: expected <group>/<name>", ErrInvalidPluginName, s)
+	}
+
+	for _, segment := range segments {
+		if !refPathSegment.MatchString(segment) {
+			return "", "", fmt.Errorf("%w: %q: invalid path segment %q", ErrInvalidPluginName, s, segment)
+		}
+	}
+
+	return strings.Join(segments[:len(segments)-1], "/"), segments[len(segments)-1], nil
+}
+
+// String formats the reference, ParsePluginRef(ref.String()) returns the same reference.
+func (r PluginRef) String() string {
+	var b strings.Builder
+
+	b.WriteString(r.Group)
+	b.WriteByte('/')
+	b.WriteString(r.Name)
+
+	if r.Version != "" {
+		b.WriteByte(':')
+		b.WriteString(r.Version)
+	}
+
+	if r.Digest != "" {
+		b.WriteByte('@')
+		b.WriteString(r.Digest)
+	}
+
+	return b.String()
+}
+
+// IsExactVersion reports whether the reference points to an exact semantic version.
+func (r PluginRef) IsExactVersion() bool {
+	_, err := ParseVersion(r.Version)
+	return err == nil
+}
+
+// IsConstraint reports whether the reference points to a version range (e.g., "^v1.36").
+func (r PluginRef) IsConstraint() bool {
+	return IsConstraint(r.Version)
+}
+
+// ValidateChannel checks that the name can be used for a channel: lowercase letters, digits and dashes,
+// neither "latest" nor a version or a version constraint.
+func ValidateChannel(channel string) error {
+	ref := PluginRef{Version: channel}
+	if !refChannel.MatchString(channel) || channel == Latest || !ref.IsChannel() {
+		return fmt.Errorf("%w: %q", ErrInvalidChannel, channel)
+	}
+
+	return nil
+}
+
+// IsChannel reports whether the reference may point to a named channel such as "stable" or "latest",
+// a version with the same name takes precedence.
+func (r PluginRef) IsChannel() bool {
+	return r.Version != "" && !r.IsExactVersion() && !r.IsConstraint()
+}
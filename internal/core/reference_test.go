@@ -0,0 +1,109 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const testDigest = "sha256:4f1a0c2d8e5b7a9c3d6e1f0a2b4c6d8e0f1a3b5c7d9e1f2a4b6c8d0e2f4a6b8c"
+
+func TestParsePluginRef(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in      string
+		want    PluginRef
+		wantErr bool
+	}{
+		{in: "protocolbuffers/go:v1.36.10", want: PluginRef{Group: "protocolbuffers", Name: "go", Version: "v1.36.10"}},
+		{in: "org/team/plugin:latest", want: PluginRef{Group: "org/team", Name: "plugin", Version: Latest}},
+		{in: "grpc/go:stable", want: PluginRef{Group: "grpc", Name: "go", Version: "stable"}},
+		{in: "grpc/go:^v1.5", want: PluginRef{Group: "grpc", Name: "go", Version: "^v1.5"}},
+		{in: "grpc/go:>=v1.5 <v2", want: PluginRef{Group: "grpc", Name: "go", Version: ">=v1.5 <v2"}},
+		{in: "grpc/go:v1.2.3+build", want: PluginRef{Group: "grpc", Name: "go", Version: "v1.2.3+build"}},
+		{in: "grpc/go:v1.2.3-rc.1+build.5", want: PluginRef{Group: "grpc", Name: "go", Version: "v1.2.3-rc.1+build.5"}},
+		{in: "grpc/go@" + testDigest, want: PluginRef{Group: "grpc", Name: "go", Digest: testDigest}},
+		{in: "grpc/go:v1.5.1@" + testDigest, want: PluginRef{Group: "grpc", Name: "go", Version: "v1.5.1", Digest: testDigest}},
+
+		{in: "", wantErr: true},
+		{in: "go:v1.0.0", wantErr: true},
+		{in: "grpc/go", wantErr: true},
+		{in: "grpc/go:", wantErr: true},
+		{in: "grpc/go@sha256:abc", wantErr: true},
+		{in: "grpc/go:v1.0.0@", wantErr: true},
+		{in: "Grpc/go:v1.0.0", wantErr: true},
+		{in: "grpc//go:v1.0.0", wantErr: true},
+		{in: "/grpc/go:v1.0.0", wantErr: true},
+		{in: "grpc/go:v1.0.0+", wantErr: true},
+		{in: "grpc/go:^x", wantErr: true},
+		{in: "grpc/go:.hidden", wantErr: true},
+		{in: "grpc/go:" + strings.Repeat("a", 129), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParsePluginRef(tt.in)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidPluginName) {
+					t.Fatalf("ParsePluginRef(%q) = %+v, %v; want ErrInvalidPluginName", tt.in, got, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParsePluginRef(%q): %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Fatalf("ParsePluginRef(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+			if got.String() != tt.in {
+				t.Fatalf("ParsePluginRef(%q).String() = %q", tt.in, got.String())
+			}
+		})
+	}
+}
+
+func FuzzParsePluginRef(f *testing.F) {
+	for _, seed := range []string{
+		"protocolbuffers/go:v1.36.10",
+		"org/team/plugin:latest",
+		"grpc/go:stable",
+		"grpc/go:^v1.5",
+		"grpc/go:~v2.27.0",
+		"grpc/go:>=v1.5 <v2",
+		"grpc/go:v1.2.3+build",
+		"grpc/go@" + testDigest,
+		"grpc/go:v1.5.1@" + testDigest,
+		"a/b:c:d",
+		"a/b:c/d@x",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		ref, err := ParsePluginRef(s)
+		if err != nil {
+			if !errors.Is(err, ErrInvalidPluginName) {
+				t.Fatalf("ParsePluginRef(%q): error doesn't wrap ErrInvalidPluginName: %v", s, err)
+			}
+
+			return
+		}
+
+		if ref.Group == "" || ref.Name == "" || (ref.Version == "" && ref.Digest == "") {
+			t.Fatalf("ParsePluginRef(%q) = %+v: incomplete reference", s, ref)
+		}
+
+		again, err := ParsePluginRef(ref.String())
+		if err != nil {
+			t.Fatalf("ParsePluginRef(%q) = %+v, reparsing %q: %v", s, ref, ref.String(), err)
+		}
+		if again != ref {
+			t.Fatalf("ParsePluginRef(%q) = %+v, reparsing %q = %+v", s, ref, ref.String(), again)
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// RetryPolicy controls retries of transient plugin execution failures.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of executions, 1 disables retries.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry, it doubles with every retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries.
+	MaxBackoff time.Duration
+}
+
+// backoff returns a jittered delay before the retry following the attempt (counted from 1).
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.InitialBackoff
+	for range attempt - 1 {
+		delay *= 2
+		if p.MaxBackoff > 0 && delay >= p.MaxBackoff {
+			delay = p.MaxBackoff
+			break
+		}
+	}
+
+	if delay <= 0 {
+		return 0
+	}
+
+	// Full jitter keeps retries of concurrent requests apart.
+	return rand.N(delay) + 1
+}
+
+// execute runs the plugin, retrying transient failures according to the plugin retry policy,
+// and records retries in metrics.
+func (c *Core) execute(
+	ctx context.Context,
+	plugin Plugin,
+	info PluginInfo,
+	req *pluginpb.CodeGeneratorRequest,
+) (*pluginpb.CodeGeneratorResponse, error) {
+	return retry(ctx, plugin, req, func(failure ExecutionFailure) error {
+		err := c.metrics.Retry(ctx, info, failure)
+		if err != nil {
+			return fmt.Errorf("c.metrics.Retry: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// retry runs the plugin, retrying transient failures according to the plugin retry policy.
+// A retry is skipped if it can't start before the request deadline. onRetry, if not nil, is called before every retry.
+func retry(
+	ctx context.Context,
+	plugin Plugin,
+	req *pluginpb.CodeGeneratorRequest,
+	onRetry func(failure ExecutionFailure) error,
+) (*pluginpb.CodeGeneratorResponse, error) {
+	policy := plugin.RetryPolicy(ctx)
+
+	for attempt := 1; ; attempt++ {
+		resp, err := plugin.Generate(ctx, req)
+
+		var execErr *ExecutionError
+		if err == nil || !errors.As(err, &execErr) || !execErr.Transient || attempt >= policy.MaxAttempts {
+			return resp, err
+		}
+
+		delay := policy.backoff(attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+			return nil, err
+		}
+
+		if onRetry != nil {
+			retryErr := onRetry(execErr.Failure)
+			if retryErr != nil {
+				return nil, fmt.Errorf("onRetry: %w", retryErr)
+			}
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
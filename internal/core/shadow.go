@@ -0,0 +1,172 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/sipki-tech/dev-platform/logger"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// maxShadowRuns limits concurrent shadow executions, requests are not shadowed while the limit is reached.
+const maxShadowRuns = 4
+
+// ShadowPolicy configures shadow execution of a candidate version of the plugin.
+type ShadowPolicy struct {
+	// Version is the exact candidate version, empty disables shadowing.
+	Version string
+	// Fraction of requests which are also run against the candidate, from 0 to 1.
+	Fraction float64
+}
+
+// ShadowOutcome is the result of a shadow execution.
+type ShadowOutcome string
+
+// Shadow execution outcomes.
+const (
+	// ShadowIdentical means the candidate produced the same response.
+	ShadowIdentical ShadowOutcome = "identical"
+	// ShadowDivergent means the responses differ.
+	ShadowDivergent ShadowOutcome = "divergent"
+	// ShadowFailed means the candidate couldn't be executed.
+	ShadowFailed ShadowOutcome = "failed"
+)
+
+// ShadowResult is the comparison of a response with the response of a candidate version.
+type ShadowResult struct {
+	PluginID    uuid.UUID
+	CandidateID uuid.UUID
+	Outcome     ShadowOutcome
+	// Files is the number of distinct files generated by either version.
+	Files int
+	// Differing lists files which differ, see DeterminismCheck.Files.
+	Differing []string
+	// Error describes the failure of the candidate, empty unless the outcome is ShadowFailed.
+	Error string
+	RunAt time.Time
+}
+
+// sample reports whether the request must be shadowed.
+func (p ShadowPolicy) sample(version string) bool {
+	return p.Version != "" && p.Version != version && p.Fraction > 0 && rand.Float64() < p.Fraction
+}
+
+// startShadow runs the request against the candidate version in the background
+// if the plugin shadows it, the response to the user isn't affected.
+func (c *Core) startShadow(
+	ctx context.Context,
+	plugin Plugin,
+	info PluginInfo,
+	req *pluginpb.CodeGeneratorRequest,
+	resp *pluginpb.CodeGeneratorResponse,
+) {
+	policy := plugin.ShadowPolicy(ctx)
+	if !policy.sample(info.Version) {
+		return
+	}
+
+	select {
+	case c.shadows <- struct{}{}:
+	default:
+		return
+	}
+
+	// The request context is cancelled when the response is sent.
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		defer func() { <-c.shadows }()
+
+		err := c.shadow(ctx, info, policy.Version, req, resp)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to record shadow execution",
+				slog.String("plugin", info.Group+"/"+info.Name+":"+info.Version),
+				slog.String("candidate", policy.Version),
+				slog.String(logger.Error.String(), err.Error()),
+			)
+		}
+	}()
+}
+
+// shadow runs the request against the candidate version, compares the responses file by file
+// and records the result.
+func (c *Core) shadow(
+	ctx context.Context,
+	info PluginInfo,
+	version string,
+	req *pluginpb.CodeGeneratorRequest,
+	resp *pluginpb.CodeGeneratorResponse,
+) error {
+	candidate, err := c.registry.Get(ctx, PluginRef{Group: info.Group, Name: info.Name, Version: version})
+	if err != nil {
+		return fmt.Errorf("c.registry.Get: %w", err)
+	}
+
+	candidateInfo := *candidate.Info(ctx)
+	result := ShadowResult{
+		PluginID:    info.ID,
+		CandidateID: candidateInfo.ID,
+		RunAt:       time.Now(),
+	}
+
+	candidateResp, err := c.runCandidate(ctx, candidate, candidateInfo, req)
+	switch {
+	case err != nil:
+		result.Outcome = ShadowFailed
+		result.Error = err.Error()
+	default:
+		result.Differing = diffResponses(resp, candidateResp)
+		result.Files = countFiles(resp, candidateResp)
+
+		result.Outcome = ShadowIdentical
+		if len(result.Differing) > 0 {
+			result.Outcome = ShadowDivergent
+		}
+	}
+
+	err = c.metrics.Shadow(ctx, info, candidateInfo, result)
+	if err != nil {
+		return fmt.Errorf("c.metrics.Shadow: %w", err)
+	}
+
+	err = c.registry.SaveShadowResult(ctx, result)
+	if err != nil {
+		return fmt.Errorf("c.registry.SaveShadowResult: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Core) runCandidate(
+	ctx context.Context,
+	candidate Plugin,
+	info PluginInfo,
+	req *pluginpb.CodeGeneratorRequest,
+) (*pluginpb.CodeGeneratorResponse, error) {
+	err := c.verifier.Verify(ctx, info)
+	if err != nil {
+		return nil, fmt.Errorf("c.verifier.Verify: %w", err)
+	}
+
+	resp, err := c.execute(ctx, candidate, info, req)
+	if err != nil {
+		return nil, fmt.Errorf("c.execute: %w", err)
+	}
+
+	return resp, nil
+}
+
+// countFiles returns the number of distinct file names in the responses.
+func countFiles(a, b *pluginpb.CodeGeneratorResponse) int {
+	names := make(map[string]bool, len(a.GetFile()))
+	for _, resp := range []*pluginpb.CodeGeneratorResponse{a, b} {
+		for _, file := range resp.GetFile() {
+			names[file.GetName()] = true
+		}
+	}
+
+	return len(names)
+}
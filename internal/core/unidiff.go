@@ -0,0 +1,200 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// diffContext is the number of unchanged lines around changes in unified diff hunks.
+	diffContext = 3
+	// maxDiffEdits bounds the work of the line diff, files which differ more are diffed
+	// as a removal of all old lines and an addition of all new ones.
+	maxDiffEdits = 1000
+)
+
+type (
+	editOp int8
+
+	// edit is a step of an edit script, A and B are positions in the old and new lines before the step.
+	edit struct {
+		Op   editOp
+		A, B int
+	}
+)
+
+const (
+	editEqual editOp = iota
+	editDelete
+	editInsert
+)
+
+// splitLines splits the text into lines keeping the line endings.
+func splitLines(text string) []string {
+	var lines []string
+	for line := range strings.Lines(text) {
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// diffLines returns the shortest edit script turning a into b, computed with the Myers algorithm.
+func diffLines(a, b []string) []edit {
+	n, m := len(a), len(b)
+	limit := min(n+m, maxDiffEdits)
+
+	// v[offset+k] is the furthest x reached on diagonal k, trace[d] is the part of v
+	// for diagonals -d-1..d+1 before step d.
+	offset := limit + 1
+	v := make([]int, 2*offset+1)
+	var trace [][]int
+
+	for d := 0; d <= limit; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				return backtrack(trace, n, m)
+			}
+		}
+	}
+
+	edits := make([]edit, 0, n+m)
+	for i := range n {
+		edits = append(edits, edit{Op: editDelete, A: i, B: 0})
+	}
+	for j := range m {
+		edits = append(edits, edit{Op: editInsert, A: n, B: j})
+	}
+
+	return edits
+}
+
+func backtrack(trace [][]int, x, y int) []edit {
+	var edits []edit
+
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		at := func(k int) int { return v[k+d+1] }
+
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY && x > 0 && y > 0 {
+			x--
+			y--
+			edits = append(edits, edit{Op: editEqual, A: x, B: y})
+		}
+
+		if d == 0 {
+			break
+		}
+
+		if x == prevX {
+			y--
+			edits = append(edits, edit{Op: editInsert, A: x, B: y})
+		} else {
+			x--
+			edits = append(edits, edit{Op: editDelete, A: x, B: y})
+		}
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+
+	return edits
+}
+
+// writeHunks writes unified diff hunks turning a into b.
+func writeHunks(w *strings.Builder, a, b []string) {
+	edits := diffLines(a, b)
+
+	for start := 0; start < len(edits); {
+		if edits[start].Op == editEqual {
+			start++
+			continue
+		}
+
+		// Extend the hunk while changes are separated by less than two contexts.
+		end := start
+		for i := start; i < len(edits) && i-end <= 2*diffContext; i++ {
+			if edits[i].Op != editEqual {
+				end = i + 1
+			}
+		}
+
+		first := max(start-diffContext, 0)
+		last := min(end+diffContext, len(edits))
+		writeHunk(w, a, b, edits[first:last])
+
+		start = last
+	}
+}
+
+func writeHunk(w *strings.Builder, a, b []string, edits []edit) {
+	countA, countB := 0, 0
+	for _, e := range edits {
+		if e.Op != editInsert {
+			countA++
+		}
+		if e.Op != editDelete {
+			countB++
+		}
+	}
+
+	fmt.Fprintf(w, "@@ -%s +%s @@\n", hunkRange(edits[0].A, countA), hunkRange(edits[0].B, countB))
+
+	for _, e := range edits {
+		switch e.Op {
+		case editEqual:
+			writeLine(w, ' ', a[e.A])
+		case editDelete:
+			writeLine(w, '-', a[e.A])
+		case editInsert:
+			writeLine(w, '+', b[e.B])
+		}
+	}
+}
+
+// hunkRange formats the start line and the number of lines of a hunk,
+// an empty range starts at the line before it.
+func hunkRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	default:
+		return fmt.Sprintf("%d,%d", start+1, count)
+	}
+}
+
+func writeLine(w *strings.Builder, prefix byte, line string) {
+	w.WriteByte(prefix)
+	w.WriteString(line)
+
+	if !strings.HasSuffix(line, "\n") {
+		w.WriteString("\n\\ No newline at end of file\n")
+	}
+}
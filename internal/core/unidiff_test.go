@@ -0,0 +1,226 @@
+package core
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		before, after string
+		want          string
+	}{
+		{
+			name:   "identical",
+			before: "a\nb\n",
+			after:  "a\nb\n",
+			want:   "",
+		},
+		{
+			name:   "context is trimmed and distant changes get own hunks",
+			before: "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\nn\n",
+			after:  "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\nL\nm\nn\n",
+			want: "@@ -1,5 +1,5 @@\n a\n-b\n+B\n c\n d\n e\n" +
+				"@@ -9,6 +9,6 @@\n i\n j\n k\n-l\n+L\n m\n n\n",
+		},
+		{
+			name:   "changes six lines apart share a hunk",
+			before: "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n",
+			after:  "A\nb\nc\nd\ne\nf\ng\nH\ni\nj\n",
+			want:   "@@ -1,10 +1,10 @@\n-a\n+A\n b\n c\n d\n e\n f\n g\n-h\n+H\n i\n j\n",
+		},
+		{
+			name:   "changes seven lines apart are split",
+			before: "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n",
+			after:  "A\nb\nc\nd\ne\nf\ng\nh\nI\nj\n",
+			want: "@@ -1,4 +1,4 @@\n-a\n+A\n b\n c\n d\n" +
+				"@@ -6,5 +6,5 @@\n f\n g\n h\n-i\n+I\n j\n",
+		},
+		{
+			name:   "append",
+			before: "a\nb\n",
+			after:  "a\nb\nc\n",
+			want:   "@@ -1,2 +1,3 @@\n a\n b\n+c\n",
+		},
+		{
+			name:   "remove the first line",
+			before: "a\nb\nc\n",
+			after:  "b\nc\n",
+			want:   "@@ -1,3 +1,2 @@\n-a\n b\n c\n",
+		},
+		{
+			name:   "single line ranges",
+			before: "a\n",
+			after:  "b\n",
+			want:   "@@ -1 +1 @@\n-a\n+b\n",
+		},
+		{
+			name:   "no newline at end of file",
+			before: "x\ny",
+			after:  "x\nz",
+			want: "@@ -1,2 +1,2 @@\n x\n-y\n\\ No newline at end of file\n" +
+				"+z\n\\ No newline at end of file\n",
+		},
+		{
+			name:   "newline added at end of file",
+			before: "x",
+			after:  "x\n",
+			want:   "@@ -1 +1 @@\n-x\n\\ No newline at end of file\n+x\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			want := "--- a/file.txt\n+++ b/file.txt\n" + tt.want
+			got := unifiedDiff("file.txt", tt.before, tt.after, true, true)
+			if got != want {
+				t.Fatalf("unifiedDiff() =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestUnifiedDiffAddedRemovedBinary(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		before, after  string
+		inBase, inHead bool
+		want           string
+	}{
+		{
+			name:   "added",
+			after:  "x\ny\n",
+			inHead: true,
+			want:   "--- /dev/null\n+++ b/f.go\n@@ -0,0 +1,2 @@\n+x\n+y\n",
+		},
+		{
+			name:   "removed",
+			before: "x\n",
+			inBase: true,
+			want:   "--- a/f.go\n+++ /dev/null\n@@ -1 +0,0 @@\n-x\n",
+		},
+		{
+			name:   "binary",
+			before: "\xff\xfe",
+			after:  "x",
+			inBase: true,
+			inHead: true,
+			want:   "Binary files a/f.go and b/f.go differ\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := unifiedDiff("f.go", tt.before, tt.after, tt.inBase, tt.inHead)
+			if got != tt.want {
+				t.Fatalf("unifiedDiff() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffLinesFallback(t *testing.T) {
+	t.Parallel()
+
+	// Both versions share a line, but they differ in more than maxDiffEdits lines.
+	var a, b []string
+	for i := range maxDiffEdits/2 + 1 {
+		a = append(a, fmt.Sprintf("old %d\n", i))
+		b = append(b, fmt.Sprintf("new %d\n", i))
+	}
+	a = append(a, "shared\n")
+	b = append(b, "shared\n")
+
+	edits := diffLines(a, b)
+	if len(edits) != len(a)+len(b) {
+		t.Fatalf("diffLines returned %d edits, want %d", len(edits), len(a)+len(b))
+	}
+	for i, e := range edits {
+		if e.Op == editEqual {
+			t.Fatalf("edit %d keeps line %q, the fallback must replace every line", i, a[e.A])
+		}
+	}
+
+	var w strings.Builder
+	writeHunks(&w, a, b)
+	header := fmt.Sprintf("@@ -1,%d +1,%d @@\n", len(a), len(b))
+	if !strings.HasPrefix(w.String(), header) || strings.Count(w.String(), "@@ -") != 1 {
+		t.Fatalf("writeHunks() starts with %q, want a single hunk %q", w.String()[:40], header)
+	}
+}
+
+// TestDiffLinesMinimal checks that edit scripts turn a into b with the fewest edits, against an LCS table.
+func TestDiffLinesMinimal(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	randomLines := func() []string {
+		lines := make([]string, rng.IntN(12))
+		for i := range lines {
+			lines[i] = string(rune('a'+rng.IntN(4))) + "\n"
+		}
+
+		return lines
+	}
+
+	for range 2000 {
+		a, b := randomLines(), randomLines()
+		edits := diffLines(a, b)
+
+		var gotA, gotB []string
+		changes := 0
+		for _, e := range edits {
+			switch e.Op {
+			case editEqual:
+				if a[e.A] != b[e.B] {
+					t.Fatalf("diffLines(%q, %q) keeps different lines %d and %d", a, b, e.A, e.B)
+				}
+				gotA = append(gotA, a[e.A])
+				gotB = append(gotB, b[e.B])
+			case editDelete:
+				gotA = append(gotA, a[e.A])
+				changes++
+			case editInsert:
+				gotB = append(gotB, b[e.B])
+				changes++
+			}
+		}
+
+		if strings.Join(gotA, "") != strings.Join(a, "") || strings.Join(gotB, "") != strings.Join(b, "") {
+			t.Fatalf("diffLines(%q, %q) = %+v doesn't cover both versions", a, b, edits)
+		}
+		if want := len(a) + len(b) - 2*lcsLength(a, b); changes != want {
+			t.Fatalf("diffLines(%q, %q) makes %d changes, want %d", a, b, changes, want)
+		}
+	}
+}
+
+func lcsLength(a, b []string) int {
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	return lengths[0][0]
+}
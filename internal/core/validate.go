@@ -0,0 +1,116 @@
+package core
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// validateCodeGeneratorRequest checks the structural consistency of a CodeGeneratorRequest
+// the same way protoc guarantees it for local plugins.
+// Field paths in the returned violations are relative to the CodeGeneratorRequest message.
+func validateCodeGeneratorRequest(req *pluginpb.CodeGeneratorRequest) error {
+	if req == nil {
+		return &ValidationError{Violations: []FieldViolation{{
+			Field:       "",
+			Description: "code generator request is required",
+		}}}
+	}
+
+	var violations []FieldViolation
+
+	if len(req.GetFileToGenerate()) == 0 {
+		violations = append(violations, FieldViolation{
+			Field:       "file_to_generate",
+			Description: "at least one file to generate is required",
+		})
+	}
+
+	// Index of every proto_file by name, dependencies must always point backwards.
+	fileIndex := make(map[string]int, len(req.GetProtoFile()))
+	for i, file := range req.GetProtoFile() {
+		field := fmt.Sprintf("proto_file[%d]", i)
+
+		name := file.GetName()
+		switch prev, ok := fileIndex[name]; {
+		case name == "":
+			violations = append(violations, FieldViolation{
+				Field:       field + ".name",
+				Description: "file name is required",
+			})
+		case ok:
+			violations = append(violations, FieldViolation{
+				Field:       field + ".name",
+				Description: fmt.Sprintf("duplicate file %q, already defined at proto_file[%d]", name, prev),
+			})
+		}
+
+		for j, dep := range file.GetDependency() {
+			if _, ok := fileIndex[dep]; !ok {
+				violations = append(violations, FieldViolation{
+					Field:       fmt.Sprintf("%s.dependency[%d]", field, j),
+					Description: fmt.Sprintf("dependency %q must be listed in proto_file before %q", dep, name),
+				})
+			}
+		}
+
+		for j, idx := range file.GetPublicDependency() {
+			if idx < 0 || int(idx) >= len(file.GetDependency()) {
+				violations = append(violations, FieldViolation{
+					Field:       fmt.Sprintf("%s.public_dependency[%d]", field, j),
+					Description: fmt.Sprintf("index %d is out of range of dependency", idx),
+				})
+			}
+		}
+
+		for j, idx := range file.GetWeakDependency() {
+			if idx < 0 || int(idx) >= len(file.GetDependency()) {
+				violations = append(violations, FieldViolation{
+					Field:       fmt.Sprintf("%s.weak_dependency[%d]", field, j),
+					Description: fmt.Sprintf("index %d is out of range of dependency", idx),
+				})
+			}
+		}
+
+		if _, ok := fileIndex[name]; name != "" && !ok {
+			fileIndex[name] = i
+		}
+	}
+
+	toGenerate := make(map[string]int, len(req.GetFileToGenerate()))
+	for i, name := range req.GetFileToGenerate() {
+		field := fmt.Sprintf("file_to_generate[%d]", i)
+
+		if prev, ok := toGenerate[name]; ok {
+			violations = append(violations, FieldViolation{
+				Field:       field,
+				Description: fmt.Sprintf("duplicate file %q, already listed at file_to_generate[%d]", name, prev),
+			})
+
+			continue
+		}
+		toGenerate[name] = i
+
+		if _, ok := fileIndex[name]; !ok {
+			violations = append(violations, FieldViolation{
+				Field:       field,
+				Description: fmt.Sprintf("file %q is missing from proto_file", name),
+			})
+		}
+	}
+
+	for i, file := range req.GetSourceFileDescriptors() {
+		if _, ok := toGenerate[file.GetName()]; !ok {
+			violations = append(violations, FieldViolation{
+				Field:       fmt.Sprintf("source_file_descriptors[%d].name", i),
+				Description: fmt.Sprintf("file %q is not listed in file_to_generate", file.GetName()),
+			})
+		}
+	}
+
+	if len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+
+	return nil
+}
@@ -0,0 +1,314 @@
+package core
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var errInvalidVersion = errors.New("invalid version")
+
+type (
+	// Version is a semantic version with a leading "v" (e.g., "v1.36.10", "v2.0.0-rc.1").
+	Version struct {
+		Major      uint64
+		Minor      uint64
+		Patch      uint64
+		Prerelease string
+		Build      string
+	}
+
+	// Constraint is a set of version comparators which must all be satisfied.
+	//
+	// Supported syntax, comparators are separated by whitespace or commas:
+	//   - "^v1.36"        >=v1.36.0 <v2.0.0 (the leftmost non-zero component is fixed)
+	//   - "~v2.27.0"      >=v2.27.0 <v2.28.0
+	//   - ">=v1.5 <v2"    explicit comparators: =, !=, >, >=, <, <=
+	//   - "v1.36"         >=v1.36.0 <v1.37.0 (missing components match any value)
+	//
+	// Prerelease versions only satisfy a constraint if one of its comparators
+	// names a prerelease of the same major, minor and patch version.
+	Constraint struct {
+		raw         string
+		comparators []comparator
+	}
+
+	comparator struct {
+		op      string
+		version Version
+	}
+)
+
+var (
+	versionRegexp = regexp.MustCompile(`^v(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)` +
+		`(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?$`)
+	partialVersionRegexp = regexp.MustCompile(`^v(0|[1-9][0-9]*)(?:\.(0|[1-9][0-9]*))?(?:\.(0|[1-9][0-9]*))?` +
+		`(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?$`)
+	comparatorRegexp = regexp.MustCompile(`^(\^|~|=|!=|>=|>|<=|<)?(v.*)$`)
+)
+
+// ParseVersion parses a semantic version.
+func ParseVersion(s string) (Version, error) {
+	m := versionRegexp.FindStringSubmatch(s)
+	if m == nil {
+		return Version{}, fmt.Errorf("%w: %q", errInvalidVersion, s)
+	}
+
+	major, _ := strconv.ParseUint(m[1], 10, 64)
+	minor, _ := strconv.ParseUint(m[2], 10, 64)
+	patch, _ := strconv.ParseUint(m[3], 10, 64)
+
+	return Version{
+		Major:      major,
+		Minor:      minor,
+		Patch:      patch,
+		Prerelease: m[4],
+		Build:      m[5],
+	}, nil
+}
+
+// String formats the version.
+func (v Version) String() string {
+	s := fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+
+	return s
+}
+
+// IsPrerelease reports whether the version is a prerelease.
+func (v Version) IsPrerelease() bool {
+	return v.Prerelease != ""
+}
+
+// Compare returns -1, 0 or +1 depending on semantic version precedence of v and o.
+// Build metadata is ignored.
+func (v Version) Compare(o Version) int {
+	if c := cmp.Compare(v.Major, o.Major); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.Minor, o.Minor); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.Patch, o.Patch); c != 0 {
+		return c
+	}
+
+	return comparePrerelease(v.Prerelease, o.Prerelease)
+}
+
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := range min(len(as), len(bs)) {
+		an, aErr := strconv.ParseUint(as[i], 10, 64)
+		bn, bErr := strconv.ParseUint(bs[i], 10, 64)
+
+		var c int
+		switch {
+		case aErr == nil && bErr == nil:
+			c = cmp.Compare(an, bn)
+		case aErr == nil:
+			c = -1 // Numeric identifiers have lower precedence.
+		case bErr == nil:
+			c = 1
+		default:
+			c = strings.Compare(as[i], bs[i])
+		}
+
+		if c != 0 {
+			return c
+		}
+	}
+
+	return cmp.Compare(len(as), len(bs))
+}
+
+// CompareVersions orders version strings by semantic version precedence.
+// Strings which are not semantic versions sort before valid versions, lexically.
+func CompareVersions(a, b string) int {
+	av, aErr := ParseVersion(a)
+	bv, bErr := ParseVersion(b)
+
+	switch {
+	case aErr == nil && bErr == nil:
+		if c := av.Compare(bv); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a, b)
+	case aErr == nil:
+		return 1
+	case bErr == nil:
+		return -1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// IsConstraint reports whether s is a version constraint rather than an exact version or a channel name.
+func IsConstraint(s string) bool {
+	if s == "" || versionRegexp.MatchString(s) {
+		return false
+	}
+
+	return strings.ContainsAny(s, "^~<>=!, ") || partialVersionRegexp.MatchString(s)
+}
+
+// ParseConstraint parses a version constraint, see Constraint for the syntax.
+func ParseConstraint(s string) (Constraint, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == ',' })
+	if len(fields) == 0 {
+		return Constraint{}, fmt.Errorf("%w: empty constraint", errInvalidVersion)
+	}
+
+	c := Constraint{raw: s}
+	for _, field := range fields {
+		m := comparatorRegexp.FindStringSubmatch(field)
+		if m == nil {
+			return Constraint{}, fmt.Errorf("%w: constraint %q: invalid comparator %q", errInvalidVersion, s, field)
+		}
+
+		comparators, err := expandComparator(m[1], m[2])
+		if err != nil {
+			return Constraint{}, fmt.Errorf("%w: constraint %q: %w", errInvalidVersion, s, err)
+		}
+
+		c.comparators = append(c.comparators, comparators...)
+	}
+
+	return c, nil
+}
+
+// expandComparator converts a comparator with a possibly partial version into comparators with full versions.
+func expandComparator(op, raw string) ([]comparator, error) {
+	m := partialVersionRegexp.FindStringSubmatch(raw)
+	if m == nil {
+		return nil, fmt.Errorf("invalid version %q", raw)
+	}
+
+	// parts is the number of components given explicitly.
+	parts := 1
+	lower := Version{Prerelease: m[4]}
+	lower.Major, _ = strconv.ParseUint(m[1], 10, 64)
+	if m[2] != "" {
+		lower.Minor, _ = strconv.ParseUint(m[2], 10, 64)
+		parts++
+	}
+	if m[3] != "" {
+		lower.Patch, _ = strconv.ParseUint(m[3], 10, 64)
+		parts++
+	}
+
+	if lower.Prerelease != "" && parts != 3 {
+		return nil, fmt.Errorf("prerelease requires a full version %q", raw)
+	}
+
+	// next returns the first version after the range fixed by the first n components.
+	next := func(n int) Version {
+		switch n {
+		case 1:
+			return Version{Major: lower.Major + 1}
+		case 2:
+			return Version{Major: lower.Major, Minor: lower.Minor + 1}
+		default:
+			return Version{Major: lower.Major, Minor: lower.Minor, Patch: lower.Patch + 1}
+		}
+	}
+
+	switch op {
+	case "^":
+		fixed := 1
+		switch {
+		case lower.Major == 0 && lower.Minor == 0 && parts == 3:
+			fixed = 3
+		case lower.Major == 0 && parts >= 2:
+			fixed = 2
+		}
+		return []comparator{{">=", lower}, {"<", next(fixed)}}, nil
+	case "~":
+		fixed := min(parts, 2)
+		return []comparator{{">=", lower}, {"<", next(fixed)}}, nil
+	case "", "=":
+		if parts == 3 {
+			return []comparator{{"=", lower}}, nil
+		}
+		return []comparator{{">=", lower}, {"<", next(parts)}}, nil
+	case "!=":
+		if parts != 3 {
+			return nil, fmt.Errorf("%q requires a full version", op)
+		}
+		return []comparator{{op, lower}}, nil
+	case ">":
+		if parts == 3 {
+			return []comparator{{op, lower}}, nil
+		}
+		return []comparator{{">=", next(parts)}}, nil
+	case "<=":
+		if parts == 3 {
+			return []comparator{{op, lower}}, nil
+		}
+		return []comparator{{"<", next(parts)}}, nil
+	default: // ">=", "<"
+		return []comparator{{op, lower}}, nil
+	}
+}
+
+// String returns the constraint as it was given.
+func (c Constraint) String() string {
+	return c.raw
+}
+
+// Check reports whether the version satisfies the constraint.
+func (c Constraint) Check(v Version) bool {
+	prereleaseAllowed := !v.IsPrerelease()
+
+	for _, comp := range c.comparators {
+		if !comp.check(v) {
+			return false
+		}
+
+		if v.IsPrerelease() && comp.version.IsPrerelease() &&
+			comp.version.Major == v.Major && comp.version.Minor == v.Minor && comp.version.Patch == v.Patch {
+			prereleaseAllowed = true
+		}
+	}
+
+	return prereleaseAllowed
+}
+
+func (c comparator) check(v Version) bool {
+	r := v.Compare(c.version)
+
+	switch c.op {
+	case "=":
+		return r == 0
+	case "!=":
+		return r != 0
+	case ">":
+		return r > 0
+	case ">=":
+		return r >= 0
+	case "<":
+		return r < 0
+	case "<=":
+		return r <= 0
+	default:
+		return false
+	}
+}
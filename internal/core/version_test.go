@@ -0,0 +1,348 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in      string
+		want    Version
+		wantErr bool
+	}{
+		{in: "v1.36.10", want: Version{Major: 1, Minor: 36, Patch: 10}},
+		{in: "v0.0.0", want: Version{}},
+		{in: "v2.0.0-rc.1", want: Version{Major: 2, Prerelease: "rc.1"}},
+		{in: "v1.2.3+build.5", want: Version{Major: 1, Minor: 2, Patch: 3, Build: "build.5"}},
+		{in: "v1.2.3-beta-2.x+sha.abc", want: Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "beta-2.x", Build: "sha.abc"}},
+
+		{in: "", wantErr: true},
+		{in: "1.2.3", wantErr: true},
+		{in: "v1.2", wantErr: true},
+		{in: "v01.2.3", wantErr: true},
+		{in: "v1.2.3-", wantErr: true},
+		{in: "v1.2.3+", wantErr: true},
+		{in: "v1.2.3-rc..1", wantErr: true},
+		{in: "latest", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseVersion(tt.in)
+			if tt.wantErr {
+				if !errors.Is(err, errInvalidVersion) {
+					t.Fatalf("ParseVersion(%q) = %+v, %v; want errInvalidVersion", tt.in, got, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseVersion(%q): %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Fatalf("ParseVersion(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+			if got.String() != tt.in {
+				t.Fatalf("ParseVersion(%q).String() = %q", tt.in, got.String())
+			}
+		})
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	t.Parallel()
+
+	// Each version has a lower precedence than the next one.
+	ordered := []string{
+		"v0.9.0",
+		"v1.0.0-alpha",
+		"v1.0.0-alpha.1",
+		"v1.0.0-alpha.beta",
+		"v1.0.0-beta",
+		"v1.0.0-beta.2",
+		"v1.0.0-beta.11",
+		"v1.0.0-rc.1",
+		"v1.0.0",
+		"v1.9.0",
+		"v1.10.0",
+		"v1.10.2",
+		"v1.10.10",
+		"v2.0.0",
+	}
+
+	for i := range ordered {
+		for j := range ordered {
+			a, err := ParseVersion(ordered[i])
+			if err != nil {
+				t.Fatalf("ParseVersion(%q): %v", ordered[i], err)
+			}
+			b, err := ParseVersion(ordered[j])
+			if err != nil {
+				t.Fatalf("ParseVersion(%q): %v", ordered[j], err)
+			}
+
+			want := 0
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = 1
+			}
+
+			if got := a.Compare(b); got != want {
+				t.Errorf("%s.Compare(%s) = %d, want %d", a, b, got, want)
+			}
+		}
+	}
+
+	a, _ := ParseVersion("v1.2.3+one")
+	b, _ := ParseVersion("v1.2.3+two")
+	if got := a.Compare(b); got != 0 {
+		t.Errorf("%s.Compare(%s) = %d, build metadata must be ignored", a, b, got)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "v1.9.0", b: "v1.10.0", want: -1},
+		{a: "v1.2.3+one", b: "v1.2.3+two", want: -1},
+		{a: "v1.2.3", b: "v1.2.3", want: 0},
+		{a: "custom", b: "v0.0.1", want: -1},
+		{a: "v0.0.1", b: "custom", want: 1},
+		{a: "a", b: "b", want: -1},
+	}
+
+	for _, tt := range tests {
+		if got := CompareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestConstraintCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		constraint string
+		match      []string
+		noMatch    []string
+	}{
+		{
+			constraint: "^v1.36",
+			match:      []string{"v1.36.0", "v1.36.10", "v1.99.0"},
+			noMatch:    []string{"v1.35.9", "v2.0.0", "v1.37.0-rc.1"},
+		},
+		{
+			constraint: "^v0.3.1",
+			match:      []string{"v0.3.1", "v0.3.9"},
+			noMatch:    []string{"v0.3.0", "v0.4.0"},
+		},
+		{
+			constraint: "^v0.0.3",
+			match:      []string{"v0.0.3"},
+			noMatch:    []string{"v0.0.4"},
+		},
+		{
+			constraint: "~v2.27.0",
+			match:      []string{"v2.27.0", "v2.27.5"},
+			noMatch:    []string{"v2.26.9", "v2.28.0"},
+		},
+		{
+			constraint: "~v2",
+			match:      []string{"v2.0.0", "v2.99.0"},
+			noMatch:    []string{"v3.0.0"},
+		},
+		{
+			constraint: ">=v1.5 <v2",
+			match:      []string{"v1.5.0", "v1.10.0", "v1.99.99"},
+			noMatch:    []string{"v1.4.9", "v2.0.0", "v2.0.0-rc.1"},
+		},
+		{
+			constraint: ">=v1.5,<v2,!=v1.7.0",
+			match:      []string{"v1.6.0", "v1.7.1"},
+			noMatch:    []string{"v1.7.0"},
+		},
+		{
+			constraint: ">v1.5",
+			match:      []string{"v1.6.0"},
+			noMatch:    []string{"v1.5.9"},
+		},
+		{
+			constraint: "<=v1.5",
+			match:      []string{"v1.5.9"},
+			noMatch:    []string{"v1.6.0"},
+		},
+		{
+			constraint: "v1.36",
+			match:      []string{"v1.36.0", "v1.36.10"},
+			noMatch:    []string{"v1.37.0", "v1.35.0"},
+		},
+		{
+			constraint: "=v1.2.3",
+			match:      []string{"v1.2.3", "v1.2.3+build"},
+			noMatch:    []string{"v1.2.4"},
+		},
+		{
+			// A prerelease comparator admits prereleases of the same version only.
+			constraint: ">=v2.0.0-rc.1",
+			match:      []string{"v2.0.0-rc.1", "v2.0.0-rc.2", "v2.0.0", "v2.1.0"},
+			noMatch:    []string{"v2.0.0-beta", "v2.1.0-rc.1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			t.Parallel()
+
+			c, err := ParseConstraint(tt.constraint)
+			if err != nil {
+				t.Fatalf("ParseConstraint(%q): %v", tt.constraint, err)
+			}
+			if !IsConstraint(tt.constraint) {
+				t.Fatalf("IsConstraint(%q) = false", tt.constraint)
+			}
+
+			for _, raw := range tt.match {
+				v, err := ParseVersion(raw)
+				if err != nil {
+					t.Fatalf("ParseVersion(%q): %v", raw, err)
+				}
+				if !c.Check(v) {
+					t.Errorf("%q.Check(%s) = false, want true", tt.constraint, raw)
+				}
+			}
+
+			for _, raw := range tt.noMatch {
+				v, err := ParseVersion(raw)
+				if err != nil {
+					t.Fatalf("ParseVersion(%q): %v", raw, err)
+				}
+				if c.Check(v) {
+					t.Errorf("%q.Check(%s) = true, want false", tt.constraint, raw)
+				}
+			}
+		})
+	}
+}
+
+func TestParseConstraintErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, in := range []string{
+		"",
+		" , ",
+		"^x",
+		"^1.2",
+		">=v1.2 foo",
+		"!=v1.2",
+		"v1.2-rc.1",
+		"^v01.2",
+	} {
+		_, err := ParseConstraint(in)
+		if !errors.Is(err, errInvalidVersion) {
+			t.Errorf("ParseConstraint(%q) = %v, want errInvalidVersion", in, err)
+		}
+	}
+}
+
+func TestIsConstraint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{in: "v1.2.3", want: false},
+		{in: "v1.2.3-rc.1", want: false},
+		{in: "stable", want: false},
+		{in: "latest", want: false},
+		{in: "", want: false},
+		{in: "v1", want: true},
+		{in: "v1.2", want: true},
+		{in: "^v1.2.3", want: true},
+		{in: ">=v1 <v2", want: true},
+	}
+
+	for _, tt := range tests {
+		if got := IsConstraint(tt.in); got != tt.want {
+			t.Errorf("IsConstraint(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+// listRegistry serves List from a fixed set of versions, other methods aren't implemented.
+type listRegistry struct {
+	Registry
+
+	plugins []PluginInfo
+}
+
+func (r listRegistry) List(context.Context, PluginFilter) ([]PluginInfo, error) {
+	return r.plugins, nil
+}
+
+func TestResolveVersion(t *testing.T) {
+	t.Parallel()
+
+	plugins := []PluginInfo{
+		{Group: "grpc", Name: "go", Version: "v1.9.0", Status: PluginAvailable},
+		{Group: "grpc", Name: "go", Version: "v1.10.0", Status: PluginAvailable},
+		{Group: "grpc", Name: "go", Version: "v1.11.0-rc.1", Status: PluginAvailable},
+		{Group: "grpc", Name: "go", Version: "v1.12.0", Status: PluginFailed},
+		{Group: "grpc", Name: "go", Version: "v2.0.0-beta.1", Status: PluginAvailable},
+		{Group: "grpc", Name: "go", Version: "custom", Status: PluginAvailable},
+	}
+	c := &Core{registry: listRegistry{plugins: plugins}}
+
+	tests := []struct {
+		version string
+		want    string
+		wantErr error
+	}{
+		{version: Latest, want: "v1.10.0"},
+		{version: "^v1", want: "v1.10.0"},
+		{version: "v1.9", want: "v1.9.0"},
+		{version: ">=v1.11.0-rc.1", want: "v1.11.0-rc.1"},
+		{version: "^v2", wantErr: ErrNotFound},
+		{version: "^v2.0.0-beta.1", want: "v2.0.0-beta.1"},
+		{version: "^v3", wantErr: ErrNotFound},
+		{version: "^x", wantErr: ErrInvalidPluginName},
+		{version: "v1.12.0", want: "v1.12.0"},
+		{version: "stable", want: "stable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			t.Parallel()
+
+			ref := PluginRef{Group: "grpc", Name: "go", Version: tt.version}
+			got, err := c.resolveVersion(context.Background(), ref)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("resolveVersion(%q) = %+v, %v; want %v", tt.version, got, err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("resolveVersion(%q): %v", tt.version, err)
+			}
+			if got.Version != tt.want {
+				t.Fatalf("resolveVersion(%q) = %q, want %q", tt.version, got.Version, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+-- up
+create table plugin_determinism
+(
+    plugin_id           uuid      not null references plugins (id) on delete cascade,
+    checks              bigint    not null default 0,
+    nondeterministic    bigint    not null default 0,
+    files               jsonb     not null default '[]',
+    checked_at          timestamp not null,
+    nondeterministic_at timestamp,
+
+    primary key (plugin_id)
+);
+
+-- down
+drop table plugin_determinism;
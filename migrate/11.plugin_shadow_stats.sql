@@ -0,0 +1,20 @@
+-- up
+create table plugin_shadow_stats
+(
+    plugin_id       uuid      not null references plugins (id) on delete cascade,
+    candidate_id    uuid      not null references plugins (id) on delete cascade,
+    runs            bigint    not null default 0,
+    identical       bigint    not null default 0,
+    divergent       bigint    not null default 0,
+    failed          bigint    not null default 0,
+    files           bigint    not null default 0,
+    differing_files bigint    not null default 0,
+    last_differing  jsonb     not null default '[]',
+    last_error      text      not null default '',
+    last_run_at     timestamp not null,
+
+    primary key (plugin_id, candidate_id)
+);
+
+-- down
+drop table plugin_shadow_stats;
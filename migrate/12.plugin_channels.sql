@@ -0,0 +1,32 @@
+-- up
+create table plugin_channels
+(
+    group_name text      not null,
+    name       text      not null,
+    channel    text      not null,
+    plugin_id  uuid      not null references plugins (id),
+    updated_at timestamp not null default now(),
+
+    primary key (group_name, name, channel)
+);
+
+create table plugin_channel_history
+(
+    id                 uuid      not null default gen_random_uuid(),
+    group_name         text      not null,
+    name               text      not null,
+    channel            text      not null,
+    plugin_id          uuid      not null references plugins (id) on delete cascade,
+    previous_plugin_id uuid      references plugins (id) on delete set null,
+    reason             text      not null default '',
+    moved_at           timestamp not null default now(),
+
+    primary key (id)
+);
+
+create index plugin_channel_history_channel_idx on plugin_channel_history (group_name, name, channel, moved_at);
+
+-- down
+drop table plugin_channel_history;
+
+drop table plugin_channels;
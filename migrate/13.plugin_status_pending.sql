@@ -0,0 +1,15 @@
+-- up
+-- Versions registered before fixtures got 'available' from the column default, pending versions
+-- without fixtures have nothing to wait for. New versions are pending until the registration runner
+-- runs their fixtures, or promotes them if they have none.
+update plugins
+set status = 'available'
+where status = 'pending'
+  and not exists (select 1 from plugin_fixtures f where f.plugin_id = plugins.id);
+
+alter table plugins
+    alter column status set default 'pending';
+
+-- down
+alter table plugins
+    alter column status set default 'available';
@@ -0,0 +1,9 @@
+-- up
+alter table plugins
+    add column digest text not null default '';
+create index plugins_digest_idx on plugins (group_name, name, digest) where digest <> '';
+
+-- down
+drop index plugins_digest_idx;
+alter table plugins
+    drop column digest;
@@ -0,0 +1,7 @@
+-- up
+alter table plugins
+    add column signature bytea not null default ''::bytea;
+
+-- down
+alter table plugins
+    drop column signature;
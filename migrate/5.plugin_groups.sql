@@ -0,0 +1,22 @@
+-- up
+create table plugin_groups
+(
+    group_name text      not null,
+    config     jsonb     not null default '{}',
+    created_at timestamp not null default now(),
+
+    primary key (group_name)
+);
+create table plugin_configs
+(
+    group_name text      not null,
+    name       text      not null,
+    config     jsonb     not null default '{}',
+    created_at timestamp not null default now(),
+
+    primary key (group_name, name)
+);
+
+-- down
+drop table plugin_configs;
+drop table plugin_groups;
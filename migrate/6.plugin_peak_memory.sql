@@ -0,0 +1,7 @@
+-- up
+alter table plugins
+    add column peak_memory bigint not null default 0;
+
+-- down
+alter table plugins
+    drop column peak_memory;
@@ -0,0 +1,14 @@
+-- up
+create table plugin_probes
+(
+    plugin_id  uuid      not null references plugins (id) on delete cascade,
+    healthy    boolean   not null,
+    latency_ms bigint    not null,
+    error      text      not null default '',
+    checked_at timestamp not null,
+
+    primary key (plugin_id)
+);
+
+-- down
+drop table plugin_probes;
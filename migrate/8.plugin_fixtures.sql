@@ -0,0 +1,25 @@
+-- up
+alter table plugins
+    add column status text not null default 'available';
+
+create table plugin_fixtures
+(
+    id         uuid      not null default gen_random_uuid(),
+    plugin_id  uuid      not null references plugins (id) on delete cascade,
+    name       text      not null,
+    request    bytea     not null,
+    expected   jsonb     not null default '{}',
+    passed     boolean,
+    error      text      not null default '',
+    run_at     timestamp,
+    created_at timestamp not null default now(),
+
+    unique (plugin_id, name),
+    primary key (id)
+);
+
+-- down
+drop table plugin_fixtures;
+
+alter table plugins
+    drop column status;
@@ -0,0 +1,13 @@
+-- up
+alter table plugins
+    add column supported_features bigint,
+    add column minimum_edition integer,
+    add column maximum_edition integer,
+    add column capabilities_checked_at timestamp;
+
+-- down
+alter table plugins
+    drop column supported_features,
+    drop column minimum_edition,
+    drop column maximum_edition,
+    drop column capabilities_checked_at;